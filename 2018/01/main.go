@@ -2,10 +2,12 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"sort"
 	"strconv"
 )
 
@@ -51,9 +53,21 @@ func addFrequency(r io.Reader) (*int, error) {
 	return &n, nil
 }
 
-func firstDoubleFrequency(r io.Reader) (*int, error) {
-	// well, we need to be able to repeat the list now so I guess we're putting it in memory........
-	changes := []int{}
+var errNeverRepeats = errors.New("The frequency drifts forever and never repeats")
+var errNoChanges = errors.New("There are no frequency changes")
+
+// frequencyDrift is one pass over the frequency changes
+// since every pass shifts every frequency by the same drift,
+// it's all we need to know where the device ends up on any later pass
+type frequencyDrift struct {
+	// frequencies[i] is where we are after the first i+1 changes
+	frequencies []int
+	// drift is how far one whole pass moves the frequency
+	drift int
+}
+
+func newFrequencyDrift(r io.Reader) (*frequencyDrift, error) {
+	var d frequencyDrift
 
 	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
@@ -61,27 +75,92 @@ func firstDoubleFrequency(r io.Reader) (*int, error) {
 		if err != nil {
 			return nil, err
 		}
-		changes = append(changes, i)
+		d.drift += i
+		d.frequencies = append(d.frequencies, d.drift)
 	}
 	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
 
-	n := 0
-	// byte is just something easy to ignore
-	encounteredNs := map[int]byte{0: 0x00}
-
-	// okay, let's start iterating through the freqs
-	i := 0
-	for {
-		n += changes[i%len(changes)]
-		if _, ok := encounteredNs[n]; ok {
-			return &n, nil
+	if len(d.frequencies) == 0 {
+		return nil, errNoChanges
+	}
+	return &d, nil
+}
+
+// firstRepeat finds the first frequency reached twice without replaying the changes
+//
+// on pass q (starting from 0), change i lands on frequencies[i] + q*drift
+// so frequencies[i] can only ever catch up to a frequency from the first pass
+// if they're the same distance apart mod drift, and it takes (distance / drift) passes to get there
+// the first repeat is whichever catch up happens in the fewest changes
+func (d frequencyDrift) firstRepeat() (int, error) {
+	// the first pass is the easy part, we just look for a repeat (0 counts, we start there)
+	seen := map[int]bool{0: true}
+	for _, f := range d.frequencies {
+		if seen[f] {
+			return f, nil
 		}
-		encounteredNs[n] = 0x00
+		seen[f] = true
+	}
+
+	// the second pass is exactly the same as the first, so the first change repeats
+	if d.drift == 0 {
+		return d.frequencies[0], nil
+	}
+
+	// flipping everything around lets us only ever think about climbing upwards
+	sign := 1
+	if d.drift < 0 {
+		sign = -1
+	}
+	step := sign * d.drift
 
-		i++
+	// group every first pass frequency by where it lands mod drift
+	// including 0, which is a target but never catches up to anything itself
+	// (0 + drift is the last change's frequency, already in the first pass)
+	classes := map[int][]int{}
+	for f := range seen {
+		v := sign * f
+		r := ((v % step) + step) % step
+		classes[r] = append(classes[r], v)
+	}
+	for _, class := range classes {
+		sort.Ints(class)
 	}
 
-	return nil, fmt.Errorf("Did not encounter a double frequency")
+	bestChanges := -1
+	var best int
+	for i, f := range d.frequencies {
+		v := sign * f
+		class := classes[((v%step)+step)%step]
+
+		// the closest frequency above us is the first one we'll land on
+		j := sort.SearchInts(class, v+1)
+		if j == len(class) {
+			continue
+		}
+		passes := (class[j] - v) / step
+		if changes := passes*len(d.frequencies) + i; bestChanges == -1 || changes < bestChanges {
+			bestChanges, best = changes, sign*class[j]
+		}
+	}
+
+	if bestChanges == -1 {
+		return 0, errNeverRepeats
+	}
+	return best, nil
+}
+
+func firstDoubleFrequency(r io.Reader) (*int, error) {
+	d, err := newFrequencyDrift(r)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := d.firstRepeat()
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"io"
+	"math/rand"
 	"strconv"
 	"strings"
 	"testing"
@@ -91,9 +92,70 @@ func TestFirstDoubleFrequency(t *testing.T) {
 		newTestCaseP2([]string{"+3", "+3", "+4", "-2", "-4"}, 10, nil),
 		newTestCaseP2([]string{"-6", "+3", "+8", "+5", "-6"}, 5, nil),
 		newTestCaseP2([]string{"+7", "+7", "-2", "-7", "-4"}, 14, nil),
+		newTestCaseP2([]string{"-7", "-7", "+2", "+7", "+4"}, -14, nil),
+		newTestCaseP2([]string{"+1", "+1", "+1"}, 0, errNeverRepeats),
+		newTestCaseP2([]string{"+5", "-3"}, 0, errNeverRepeats),
+		newTestCaseP2([]string{"+1000000", "-999999"}, 1000000, nil),
+		newTestCaseP2([]string{}, 0, errNoChanges),
 	} {
 		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
 			tc.Test(t)
 		})
 	}
 }
+
+// replays the changes until something repeats, like we used to
+// giving up after passes trips through the changes
+func bruteForceFirstRepeat(changes []int, passes int) (int, bool) {
+	n := 0
+	seen := map[int]bool{0: true}
+	for i := 0; i < passes*len(changes); i++ {
+		n += changes[i%len(changes)]
+		if seen[n] {
+			return n, true
+		}
+		seen[n] = true
+	}
+	return 0, false
+}
+
+func TestFirstRepeatMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		changes := make([]int, 1+r.Intn(20))
+		d := frequencyDrift{}
+		biggest := 0
+		for j := range changes {
+			changes[j] = r.Intn(41) - 20
+			d.drift += changes[j]
+			d.frequencies = append(d.frequencies, d.drift)
+			if changes[j] > biggest {
+				biggest = changes[j]
+			} else if -changes[j] > biggest {
+				biggest = -changes[j]
+			}
+		}
+
+		// every first pass frequency is within len(changes)*biggest of 0,
+		// and each pass moves everything at least 1 closer to catching up,
+		// so anything that repeats has done it by then
+		passes := 2*len(changes)*biggest + 2
+		expected, repeats := bruteForceFirstRepeat(changes, passes)
+
+		actual, err := d.firstRepeat()
+		if err == errNeverRepeats {
+			if repeats {
+				t.Fatalf("Expected %v to first repeat %d, but it never repeats", changes, expected)
+			}
+			continue
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		if !repeats {
+			t.Fatalf("Expected %v to never repeat, got %d", changes, actual)
+		} else if actual != expected {
+			t.Fatalf("Expected %v to first repeat %d, got %d", changes, expected, actual)
+		}
+	}
+}
@@ -2,16 +2,22 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 )
 
 func main() {
+	k := flag.Int("k", 0, "instead of solving, print every cluster of IDs within this distance of each other, one per line")
+	distanceName := flag.String("distance", hamming.String(), "how to measure the distance between IDs for -k (hamming or levenshtein)")
+	flag.Parse()
+
 	var f *os.File
-	if len(os.Args) == 2 {
+	if flag.NArg() == 1 {
 		var err error
-		f, err = os.Open(os.Args[1])
+		f, err = os.Open(flag.Arg(0))
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -36,6 +42,17 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if *k > 0 {
+		d, err := parseDistance(*distanceName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, cluster := range clusterNearDuplicates(inputs, *k, d) {
+			fmt.Println(strings.Join(cluster, ","))
+		}
+		return
+	}
+
 	counts := []map[int]int{}
 	for _, input := range inputs {
 		counts = append(counts, countExactlyRepeatedLetters(input))
@@ -43,7 +60,10 @@ func main() {
 	result := checksumTwosAndThrees(counts)
 	fmt.Printf("p1: %d\n", result)
 
-	str1, str2 := findOnlyOneDifferent(inputs)
+	str1, str2, err := findOnlyOneDifferent(inputs)
+	if err != nil {
+		log.Fatal(err)
+	}
 	resultP2 := findCommonCharacters(str1, str2)
 	fmt.Printf("p2: %s\n", resultP2)
 }
@@ -85,44 +105,16 @@ func checksumTwosAndThrees(counts []map[int]int) int {
 	return aggregate[2] * aggregate[3]
 }
 
-func numDifferent(str1, str2 string) int {
-	diff := 0
-
-	for pos := range str1 {
-		// don't panic, we'll account later for the diff in lengths
-		if pos >= len(str2) {
-			break
-		}
-
-		if str1[pos] != str2[pos] {
-			diff++
-		}
-	}
-
-	// non-existant letters automatically count as diffs
-	if len(str2) < len(str1) {
-		diff += len(str1) - len(str2)
-	} else if len(str1) < len(str2) {
-		diff += len(str2) - len(str1)
-	}
-
-	return diff
-}
-
-// find first result where there's only a diff of 1 between two strings in input
-func findOnlyOneDifferent(input []string) (string, string) {
-	// TODO optimization: we could start b only after pos(a)
-	// since those have all already been tested
-	for _, a := range input {
-		for _, b := range input {
-			if numDifferent(a, b) == 1 {
-				return a, b
-			}
+// find the first pair of IDs in input that differ by exactly one character
+// at the same position, so IDs of different lengths are never a pair
+// (which findCommonCharacters relies on)
+func findOnlyOneDifferent(input []string) (string, string, error) {
+	for _, n := range findNearDuplicates(input, 1, hamming) {
+		if n.distance == 1 {
+			return n.a, n.b, nil
 		}
 	}
-	// TODO
-	// but really, error
-	return "", ""
+	return "", "", errNoNearDuplicates
 }
 
 // given a string, return only the characters in common
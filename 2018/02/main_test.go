@@ -69,27 +69,11 @@ func TestChecksumsTwosAndThrees(t *testing.T) {
 
 }
 
-func TestNumDifferent(t *testing.T) {
-	type testCase struct {
-		input  []string
-		output int
-	}
-
-	for i, tc := range []testCase{
-		testCase{[]string{"abcde", "axcye"}, 2},
-		testCase{[]string{"fghij", "fguij"}, 1},
-	} {
-		t.Run(fmt.Sprintf("Case %d", i), func(t *testing.T) {
-			if result := numDifferent(tc.input[0], tc.input[1]); result != tc.output {
-				t.Fatalf("Expected %d, got %d", tc.output, result)
-			}
-		})
-	}
-}
-
 func TestFindOnlyOneDifferent(t *testing.T) {
-	str1, str2 := findOnlyOneDifferent([]string{"abcde", "axcye", "fghij", "fguij"})
-	if str1 == str2 {
+	str1, str2, err := findOnlyOneDifferent([]string{"abcde", "axcye", "fghij", "fguij"})
+	if err != nil {
+		t.Fatal(err)
+	} else if str1 == str2 {
 		t.Fatalf("Expected %q and %q to differ", str1, str2)
 	} else if str1 != "fghij" && str2 != "fghij" {
 		t.Fatalf("expected %q or %q to be \"fghij\"", str1, str2)
@@ -98,6 +82,26 @@ func TestFindOnlyOneDifferent(t *testing.T) {
 	}
 }
 
+// "abcd" is only one character off of "abcde", but it's missing one rather than different
+func TestFindOnlyOneDifferentNeedsSameLength(t *testing.T) {
+	if _, _, err := findOnlyOneDifferent([]string{"abcde", "abcd", "abcdef"}); err != errNoNearDuplicates {
+		t.Fatalf("Expected errNoNearDuplicates, got %v", err)
+	}
+
+	str1, str2, err := findOnlyOneDifferent([]string{"abcd", "abcde", "abxde"})
+	if err != nil {
+		t.Fatal(err)
+	} else if str1 != "abcde" || str2 != "abxde" {
+		t.Fatalf("Expected \"abcde\" and \"abxde\", got %q and %q", str1, str2)
+	}
+}
+
+func TestFindOnlyOneDifferentError(t *testing.T) {
+	if _, _, err := findOnlyOneDifferent([]string{"abcde", "axcye"}); err != errNoNearDuplicates {
+		t.Fatalf("Expected errNoNearDuplicates, got %v", err)
+	}
+}
+
 func TestFindCommonCharacters(t *testing.T) {
 	result := findCommonCharacters("fghij", "fguij")
 	if result != "fgij" {
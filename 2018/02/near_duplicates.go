@@ -0,0 +1,253 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var errNoNearDuplicates = errors.New("No IDs are close enough to each other")
+var errUnknownDistance = errors.New("Unknown distance")
+
+// a distance is how we decide how far apart two IDs are
+type distance int
+
+const (
+	// hamming only counts substitutions, so IDs of different lengths are never near each other
+	hamming distance = iota
+	// levenshtein counts insertions, deletions and substitutions
+	levenshtein
+)
+
+func (d distance) String() string {
+	switch d {
+	case hamming:
+		return "hamming"
+	case levenshtein:
+		return "levenshtein"
+	}
+	return fmt.Sprintf("distance(%d)", int(d))
+}
+
+func parseDistance(s string) (distance, error) {
+	for _, d := range []distance{hamming, levenshtein} {
+		if s == d.String() {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("%w %q, expected %v or %v", errUnknownDistance, s, hamming, levenshtein)
+}
+
+// between is the distance between a and b, giving up once it's bigger than k
+// returning false if they aren't within k of each other
+func (d distance) between(a, b string, k int) (int, bool) {
+	switch d {
+	case hamming:
+		return hammingDistance(a, b, k)
+	case levenshtein:
+		return levenshteinDistance(a, b, k)
+	}
+	return 0, false
+}
+
+func hammingDistance(a, b string, k int) (int, bool) {
+	if len(a) != len(b) {
+		return 0, false
+	}
+
+	diff := 0
+	for i := range a {
+		if a[i] != b[i] {
+			diff++
+			if diff > k {
+				return 0, false
+			}
+		}
+	}
+	return diff, true
+}
+
+func levenshteinDistance(a, b string, k int) (int, bool) {
+	if len(a)-len(b) > k || len(b)-len(a) > k {
+		return 0, false
+	}
+
+	// the classic table, one row at a time
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		best := cur[0]
+		for j := 1; j <= len(b); j++ {
+			substitute := prev[j-1]
+			if a[i-1] != b[j-1] {
+				substitute++
+			}
+			cur[j] = substitute
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+			if cur[j] < best {
+				best = cur[j]
+			}
+		}
+		// nothing in this row is within k, so nothing in the rest of the table can be either
+		if best > k {
+			return 0, false
+		}
+		prev, cur = cur, prev
+	}
+
+	if prev[len(b)] > k {
+		return 0, false
+	}
+	return prev[len(b)], true
+}
+
+// neighborhood is every key that s is filed under in the index
+//
+// for levenshtein, that's s with up to k characters deleted:
+// two IDs within k edits of each other always share one of those
+// for hamming, the characters are masked out instead of deleted,
+// so only IDs of the same length with the same differing positions meet
+func (d distance) neighborhood(s string, k int) map[string]bool {
+	keys := map[string]bool{}
+
+	var visit func(s []byte, from, left int)
+	visit = func(s []byte, from, left int) {
+		keys[string(s)] = true
+		if left == 0 {
+			return
+		}
+		for i := from; i < len(s); i++ {
+			next := make([]byte, 0, len(s))
+			if d == hamming {
+				if s[i] == 0 {
+					continue
+				}
+				next = append(append(append(next, s[:i]...), 0), s[i+1:]...)
+				visit(next, i+1, left-1)
+			} else {
+				next = append(append(next, s[:i]...), s[i+1:]...)
+				visit(next, i, left-1)
+			}
+		}
+	}
+	visit([]byte(s), 0, k)
+
+	return keys
+}
+
+// a nearDuplicate is two IDs that are within k of each other
+type nearDuplicate struct {
+	a, b     string // a is always the one that came first in the input
+	distance int
+}
+
+// a nearPair is a nearDuplicate by where the IDs are in the input
+type nearPair struct {
+	a, b     int
+	distance int
+}
+
+// findNearPairs finds every pair of IDs within k of each other, in input order
+// rather than comparing every pair, each ID is filed under its neighborhood
+// and only IDs that end up filed together are compared
+func findNearPairs(ids []string, k int, d distance) []nearPair {
+	index := map[string][]int{}
+	for i, id := range ids {
+		for key := range d.neighborhood(id, k) {
+			index[key] = append(index[key], i)
+		}
+	}
+
+	type pair struct{ a, b int }
+	candidates := map[pair]bool{}
+	for _, bucket := range index {
+		for x := range bucket {
+			for y := x + 1; y < len(bucket); y++ {
+				candidates[pair{bucket[x], bucket[y]}] = true
+			}
+		}
+	}
+
+	pairs := make([]pair, 0, len(candidates))
+	for p := range candidates {
+		pairs = append(pairs, p)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].a != pairs[j].a {
+			return pairs[i].a < pairs[j].a
+		}
+		return pairs[i].b < pairs[j].b
+	})
+
+	near := []nearPair{}
+	for _, p := range pairs {
+		if dist, ok := d.between(ids[p.a], ids[p.b], k); ok {
+			near = append(near, nearPair{p.a, p.b, dist})
+		}
+	}
+	return near
+}
+
+// findNearDuplicates is findNearPairs, but with the IDs themselves
+func findNearDuplicates(ids []string, k int, d distance) []nearDuplicate {
+	pairs := findNearPairs(ids, k, d)
+	near := make([]nearDuplicate, 0, len(pairs))
+	for _, p := range pairs {
+		near = append(near, nearDuplicate{ids[p.a], ids[p.b], p.distance})
+	}
+	return near
+}
+
+// clusterNearDuplicates groups IDs that are connected by a chain of near duplicates
+// each cluster, and the clusters themselves, are in input order
+// IDs without any near duplicates aren't in any cluster,
+// but an ID that shows up more than once is its own near duplicate
+func clusterNearDuplicates(ids []string, k int, d distance) [][]string {
+	// a plain old union-find, keyed by position in the input
+	parent := make([]int, len(ids))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	clustered := map[int]bool{}
+	for _, p := range findNearPairs(ids, k, d) {
+		a, b := find(p.a), find(p.b)
+		if a > b {
+			a, b = b, a
+		}
+		parent[b] = a
+		clustered[p.a] = true
+		clustered[p.b] = true
+	}
+
+	clusters := [][]string{}
+	which := map[int]int{}
+	for i, id := range ids {
+		if !clustered[i] {
+			continue
+		}
+		root := find(i)
+		if _, ok := which[root]; !ok {
+			which[root] = len(clusters)
+			clusters = append(clusters, []string{})
+		}
+		clusters[which[root]] = append(clusters[which[root]], id)
+	}
+	return clusters
+}
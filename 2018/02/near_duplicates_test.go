@@ -0,0 +1,172 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestDistances(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		d        distance
+		k        int
+		distance int
+		ok       bool
+	}{
+		{"fghij", "fguij", hamming, 1, 1, true},
+		{"abcde", "axcye", hamming, 1, 0, false},
+		{"abcde", "axcye", hamming, 2, 2, true},
+		{"abcde", "abcd", hamming, 5, 0, false},
+		{"abcde", "abcd", levenshtein, 1, 1, true},
+		{"kitten", "sitting", levenshtein, 3, 3, true},
+		{"kitten", "sitting", levenshtein, 2, 0, false},
+		{"abcdef", "bcdefa", levenshtein, 2, 2, true},
+		{"abcdef", "bcdefa", hamming, 5, 0, false},
+		{"", "", levenshtein, 0, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s %s %s", tc.d, tc.a, tc.b), func(t *testing.T) {
+			if dist, ok := tc.d.between(tc.a, tc.b, tc.k); ok != tc.ok || dist != tc.distance {
+				t.Fatalf("Expected (%d, %t), got (%d, %t)", tc.distance, tc.ok, dist, ok)
+			}
+		})
+	}
+}
+
+func TestFindNearDuplicates(t *testing.T) {
+	ids := []string{"abcde", "fghij", "klmno", "pqrst", "fguij", "axcye", "wvxyz", "fghi"}
+
+	testCases := []struct {
+		d      distance
+		k      int
+		output []nearDuplicate
+	}{
+		{hamming, 1, []nearDuplicate{{"fghij", "fguij", 1}}},
+		{hamming, 2, []nearDuplicate{{"abcde", "axcye", 2}, {"fghij", "fguij", 1}}},
+		{levenshtein, 1, []nearDuplicate{{"fghij", "fguij", 1}, {"fghij", "fghi", 1}}},
+		{levenshtein, 2, []nearDuplicate{{"abcde", "axcye", 2}, {"fghij", "fguij", 1}, {"fghij", "fghi", 1}, {"fguij", "fghi", 2}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s %d", tc.d, tc.k), func(t *testing.T) {
+			actual := findNearDuplicates(ids, tc.k, tc.d)
+			if len(actual) != len(tc.output) {
+				t.Fatalf("Expected (%+v), got (%+v)", tc.output, actual)
+			}
+			for i := range actual {
+				if actual[i] != tc.output[i] {
+					t.Fatalf("Expected (%+v), got (%+v)", tc.output, actual)
+				}
+			}
+		})
+	}
+}
+
+// the index should find exactly what comparing every pair does
+func TestFindNearDuplicatesMatchesPairwise(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	ids := make([]string, 300)
+	for i := range ids {
+		id := make([]byte, 4+r.Intn(3))
+		for j := range id {
+			id[j] = byte('a' + r.Intn(3))
+		}
+		ids[i] = string(id)
+	}
+
+	for _, d := range []distance{hamming, levenshtein} {
+		for k := 0; k <= 2; k++ {
+			expected := 0
+			seen := map[[2]string]bool{}
+			for i := range ids {
+				for j := i + 1; j < len(ids); j++ {
+					if _, ok := d.between(ids[i], ids[j], k); ok {
+						expected++
+						seen[[2]string{ids[i], ids[j]}] = true
+					}
+				}
+			}
+
+			actual := findNearDuplicates(ids, k, d)
+			if len(actual) != expected {
+				t.Errorf("%s %d: expected %d pairs, got %d", d, k, expected, len(actual))
+			}
+			for _, n := range actual {
+				if !seen[[2]string{n.a, n.b}] {
+					t.Errorf("%s %d: did not expect (%+v)", d, k, n)
+				}
+			}
+		}
+	}
+}
+
+func TestClusterNearDuplicates(t *testing.T) {
+	ids := []string{"abcde", "fghij", "klmno", "fguij", "axcde", "fguik", "zzzzz"}
+
+	clusters := clusterNearDuplicates(ids, 1, hamming)
+	expected := [][]string{{"abcde", "axcde"}, {"fghij", "fguij", "fguik"}}
+	if len(clusters) != len(expected) {
+		t.Fatalf("Expected (%q), got (%q)", expected, clusters)
+	}
+	for i := range clusters {
+		if !stringSliceEquals(clusters[i], expected[i]) {
+			t.Fatalf("Expected (%q), got (%q)", expected, clusters)
+		}
+	}
+}
+
+// repeats are near duplicates of each other, so each copy is in the cluster
+func TestClusterRepeatedIDs(t *testing.T) {
+	ids := []string{"abcde", "zzzzz", "abcde", "abcde"}
+
+	clusters := clusterNearDuplicates(ids, 1, levenshtein)
+	if expected := [][]string{{"abcde", "abcde", "abcde"}}; len(clusters) != 1 || !stringSliceEquals(clusters[0], expected[0]) {
+		t.Fatalf("Expected (%q), got (%q)", expected, clusters)
+	}
+}
+
+func TestParseDistance(t *testing.T) {
+	for _, d := range []distance{hamming, levenshtein} {
+		if actual, err := parseDistance(d.String()); err != nil || actual != d {
+			t.Errorf("Expected %v, got %v w/ err %v", d, actual, err)
+		}
+	}
+	if _, err := parseDistance("manhattan"); !errors.Is(err, errUnknownDistance) {
+		t.Errorf("Expected %v, got %v", errUnknownDistance, err)
+	}
+}
+
+func stringSliceEquals(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func BenchmarkFindNearDuplicates(b *testing.B) {
+	r := rand.New(rand.NewSource(2))
+	ids := make([]string, 10000)
+	for i := range ids {
+		id := make([]byte, 26)
+		for j := range id {
+			id[j] = byte('a' + r.Intn(26))
+		}
+		ids[i] = string(id)
+	}
+
+	for _, d := range []distance{hamming, levenshtein} {
+		b.Run(d.String(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				findNearDuplicates(ids, 1, d)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"sort"
+
+	"gitlab.com/travisby/advent/geom"
+)
+
+// box is the square inches the claim covers
+func (c claim) box() geom.Box {
+	return geom.Box{
+		Min: geom.Pt(c.point.x, c.point.y),
+		Max: geom.Pt(c.point.x+c.width-1, c.point.y+c.height-1),
+	}
+}
+
+// a conflict is two claims wanting the same fabric
+type conflict struct {
+	a, b    int // claim IDs, a is always the one that came first in the input
+	overlap geom.Box
+}
+
+// fabric is every claim laid out on a sheet that's as big as it needs to be
+// rather than remembering every square inch, it works with the claims' rectangles
+// so it doesn't care if the sheet is 1000 inches or 1,000,000 inches wide
+type fabric struct {
+	claims []claim
+}
+
+func newFabric(claims []claim) *fabric {
+	return &fabric{claims}
+}
+
+// byLeft is the index of each claim, ordered by its left edge
+func (f fabric) byLeft() []int {
+	order := make([]int, len(f.claims))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return f.claims[order[i]].point.x < f.claims[order[j]].point.x
+	})
+	return order
+}
+
+// conflicts finds every pair of overlapping claims
+// sweeping left to right, a claim only has to be checked against the claims
+// that haven't ended yet by the time it starts
+func (f fabric) conflicts() []conflict {
+	var conflicts []conflict
+
+	active := []int{}
+	for _, i := range f.byLeft() {
+		c := f.claims[i]
+
+		// forget about anything that ended before c starts
+		stillActive := active[:0]
+		for _, j := range active {
+			if o := f.claims[j]; o.point.x+o.width > c.point.x {
+				stillActive = append(stillActive, j)
+			}
+		}
+		active = stillActive
+
+		for _, j := range active {
+			if overlap, ok := f.claims[j].box().Intersect(c.box()); ok {
+				a, b := j, i
+				if a > b {
+					a, b = b, a
+				}
+				conflicts = append(conflicts, conflict{f.claims[a].id, f.claims[b].id, overlap})
+			}
+		}
+		active = append(active, i)
+	}
+
+	return conflicts
+}
+
+// contestedArea is the square inches claimed by two or more claims
+// it sweeps the vertical edges of every claim left to right
+// and, between each pair of edges, measures how much of the column is contested
+func (f fabric) contestedArea() int {
+	type edge struct {
+		x     int
+		claim int
+		start bool
+	}
+	edges := make([]edge, 0, 2*len(f.claims))
+	for i, c := range f.claims {
+		edges = append(edges, edge{c.point.x, i, true}, edge{c.point.x + c.width, i, false})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i].x < edges[j].x
+	})
+
+	var area int
+	active := map[int]bool{}
+	for i := 0; i < len(edges); {
+		x := edges[i].x
+		for ; i < len(edges) && edges[i].x == x; i++ {
+			if edges[i].start {
+				active[edges[i].claim] = true
+			} else {
+				delete(active, edges[i].claim)
+			}
+		}
+
+		if i < len(edges) {
+			area += (edges[i].x - x) * f.contestedLength(active)
+		}
+	}
+
+	return area
+}
+
+// contestedLength is how much of a single column is claimed more than once
+// between the claims in active
+func (f fabric) contestedLength(active map[int]bool) int {
+	type edge struct {
+		y     int
+		delta int
+	}
+	edges := make([]edge, 0, 2*len(active))
+	for i := range active {
+		c := f.claims[i]
+		edges = append(edges, edge{c.point.y, 1}, edge{c.point.y + c.height, -1})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i].y < edges[j].y
+	})
+
+	var length, depth int
+	for i, e := range edges {
+		if depth > 1 {
+			length += e.y - edges[i-1].y
+		}
+		depth += e.delta
+	}
+	return length
+}
+
+// contentionGraph maps every claim ID to the IDs of the claims it overlaps, lowest first
+// claims that don't overlap anything are still in the graph, with no neighbors
+func (f fabric) contentionGraph() map[int][]int {
+	graph := make(map[int][]int, len(f.claims))
+	for _, c := range f.claims {
+		graph[c.id] = []int{}
+	}
+	for _, c := range f.conflicts() {
+		graph[c.a] = append(graph[c.a], c.b)
+		graph[c.b] = append(graph[c.b], c.a)
+	}
+	for _, ids := range graph {
+		sort.Ints(ids)
+	}
+	return graph
+}
+
+// uncontested are the IDs of every claim that doesn't overlap any other, in input order
+func (f fabric) uncontested() []int {
+	graph := f.contentionGraph()
+
+	ids := []int{}
+	for _, c := range f.claims {
+		if len(graph[c.id]) == 0 {
+			ids = append(ids, c.id)
+		}
+	}
+	return ids
+}
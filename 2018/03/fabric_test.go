@@ -0,0 +1,130 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+
+	"gitlab.com/travisby/advent/geom"
+)
+
+func intSliceEquals(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sampleClaims(t testing.TB) []claim {
+	t.Helper()
+	claims := []claim{}
+	for _, s := range []string{"#1 @ 1,3: 4x4", "#2 @ 3,1: 4x4", "#3 @ 5,5: 2x2"} {
+		c, err := newClaim(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		claims = append(claims, *c)
+	}
+	return claims
+}
+
+// randomClaims are n claims somewhere on a size x size sheet
+func randomClaims(n, size int) []claim {
+	r := rand.New(rand.NewSource(3))
+	claims := make([]claim, n)
+	for i := range claims {
+		claims[i].id = i + 1
+		claims[i].width = 1 + r.Intn(30)
+		claims[i].height = 1 + r.Intn(30)
+		claims[i].point.x = r.Intn(size)
+		claims[i].point.y = r.Intn(size)
+	}
+	return claims
+}
+
+func TestFabricSample(t *testing.T) {
+	f := newFabric(sampleClaims(t))
+
+	if area := f.contestedArea(); area != 4 {
+		t.Errorf("Expected 4 contested square inches, got %d", area)
+	}
+
+	conflicts := f.conflicts()
+	expected := conflict{1, 2, geom.Box{Min: geom.Pt(3, 3), Max: geom.Pt(4, 4)}}
+	if len(conflicts) != 1 || conflicts[0] != expected {
+		t.Errorf("Expected only (%+v), got (%+v)", expected, conflicts)
+	}
+
+	if ids := f.uncontested(); !intSliceEquals(ids, []int{3}) {
+		t.Errorf("Expected only #3 to be uncontested, got %+v", ids)
+	}
+}
+
+func TestFabricIsUnbounded(t *testing.T) {
+	claims := sampleClaims(t)
+	// way off of the old 1000x1000 sheet
+	for i := range claims {
+		claims[i].point.x += 1000000
+		claims[i].point.y += 5000000
+	}
+
+	if area := newFabric(claims).contestedArea(); area != 4 {
+		t.Errorf("Expected 4 contested square inches, got %d", area)
+	}
+}
+
+// compare against counting every square inch, the way we used to
+func TestFabricMatchesCounting(t *testing.T) {
+	claims := randomClaims(500, 200)
+
+	counts := map[geom.Point]int{}
+	for _, c := range claims {
+		b := c.box()
+		for x := b.Min.X; x <= b.Max.X; x++ {
+			for y := b.Min.Y; y <= b.Max.Y; y++ {
+				counts[geom.Pt(x, y)]++
+			}
+		}
+	}
+	var expected int
+	for _, n := range counts {
+		if n > 1 {
+			expected++
+		}
+	}
+
+	f := newFabric(claims)
+	if area := f.contestedArea(); area != expected {
+		t.Errorf("Expected %d contested square inches, got %d", expected, area)
+	}
+
+	// every conflict's overlap has to be contested, and in both claims
+	for _, c := range f.conflicts() {
+		if !claims[c.a-1].box().Contains(c.overlap.Min) || !claims[c.b-1].box().Contains(c.overlap.Max) {
+			t.Errorf("Conflict %+v isn't in both claims", c)
+		} else if counts[c.overlap.Min] < 2 {
+			t.Errorf("Conflict %+v isn't contested", c)
+		}
+	}
+}
+
+func BenchmarkFabric(b *testing.B) {
+	claims := randomClaims(100000, 100000)
+	for i := 0; i < b.N; i++ {
+		f := newFabric(claims)
+		f.contestedArea()
+		f.uncontested()
+	}
+}
+
+func TestNewClaimErrors(t *testing.T) {
+	for _, s := range []string{"#1 @ 1,3", "1 @ 1,3: 4x4", "#1 @ 1,3: 0x4", "#1 @ 1,3: 4x0", "#1 @ 1,3: -1x4"} {
+		if _, err := newClaim(s); err == nil {
+			t.Errorf("Expected (%s) to be an error", s)
+		}
+	}
+}
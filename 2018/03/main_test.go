@@ -43,3 +43,61 @@ func TestNewClaim(t *testing.T) {
 	}
 
 }
+
+func TestUncontested(t *testing.T) {
+	testCases := []struct {
+		name   string
+		claims []string
+		output []int
+	}{
+		{"Sample", []string{"#1 @ 1,3: 4x4", "#2 @ 3,1: 4x4", "#3 @ 5,5: 2x2"}, []int{3}},
+		{"None", []string{"#1 @ 0,0: 2x2", "#2 @ 1,1: 2x2"}, []int{}},
+		{"All", []string{"#7 @ 0,0: 2x2", "#4 @ 2,2: 2x2", "#9 @ 0,2: 2x2"}, []int{7, 4, 9}},
+		// the old search would walk right off the end of the contested IDs for this one
+		{"Highest ID", []string{"#1 @ 0,0: 2x2", "#2 @ 1,1: 2x2", "#3 @ 9,9: 1x1"}, []int{3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := []claim{}
+			for _, s := range tc.claims {
+				c, err := newClaim(s)
+				if err != nil {
+					t.Fatal(err)
+				}
+				claims = append(claims, *c)
+			}
+
+			if actual := newFabric(claims).uncontested(); !intSliceEquals(actual, tc.output) {
+				t.Fatalf("Expected (%+v) to be (%+v)", actual, tc.output)
+			}
+		})
+	}
+}
+
+func TestContentionGraph(t *testing.T) {
+	claims := []claim{}
+	for _, s := range []string{"#1 @ 0,0: 3x3", "#2 @ 2,2: 3x3", "#3 @ 1,1: 1x1", "#4 @ 9,9: 1x1"} {
+		c, err := newClaim(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		claims = append(claims, *c)
+	}
+
+	graph := newFabric(claims).contentionGraph()
+	expected := map[int][]int{
+		1: {2, 3},
+		2: {1},
+		3: {1},
+		4: {},
+	}
+	if len(graph) != len(expected) {
+		t.Fatalf("Expected %d claims in the graph, got %+v", len(expected), graph)
+	}
+	for id, ids := range expected {
+		if actual, ok := graph[id]; !ok || !intSliceEquals(actual, ids) {
+			t.Errorf("Expected #%d to overlap (%+v), got (%+v)", id, ids, actual)
+		}
+	}
+}
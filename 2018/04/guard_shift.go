@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"strings"
 	"time"
 )
@@ -62,16 +61,99 @@ func scanGuardShift(data []byte, atEOF bool) (advance int, token []byte, err err
 	return 0, nil, nil
 }
 
+// every line starts with a timestamp like [1518-02-12 23:50]
+const timestampLayout = "2006-01-02 15:04"
+
+var errGuardShiftLine = errors.New("Unable to parse guard shift line")
+var errGuardShiftSleep = errors.New("Guard's sleeping doesn't add up")
+
+func parseLine(line string) (time.Time, string, error) {
+	// [1518-02-12 23:50] Guard #1789 begins shift
+	if len(line) < len(timestampLayout)+3 || line[0] != '[' || line[len(timestampLayout)+1] != ']' {
+		return time.Time{}, "", fmt.Errorf("%w: %q", errGuardShiftLine, line)
+	}
+
+	t, err := time.Parse(timestampLayout, line[1:len(timestampLayout)+1])
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: %q: %v", errGuardShiftLine, line, err)
+	}
+
+	return t, strings.TrimSpace(line[len(timestampLayout)+2:]), nil
+}
+
+// newGuardShift parses one token from scanGuardShift
+// the shift itself is considered to last until the end of the midnight hour
+// since that's the only hour anyone is watching
+func newGuardShift(s string) (*guardShift, error) {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+
+	begin, msg, err := parseLine(lines[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var g guardShift
+	if n, err := fmt.Sscanf(msg, "Guard #%d begins shift", &g.guardID); n != 1 || err != nil {
+		return nil, fmt.Errorf("%w: %q", errGuardShiftScan, lines[0])
+	}
+
+	// guards showing up before midnight are on duty for the next day
+	midnight := begin.Truncate(24 * time.Hour)
+	if begin.Hour() != 0 {
+		midnight = midnight.Add(24 * time.Hour)
+	}
+	end := midnight.Add(time.Hour)
+	g.shift = period{begin, end.Sub(begin)}
+
+	var asleep *time.Time
+	for _, line := range lines[1:] {
+		t, msg, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		} else if t.Before(begin) || !t.Before(end) {
+			return nil, fmt.Errorf("%w: %q is outside of guard #%d's shift", errGuardShiftSleep, line, g.guardID)
+		}
+
+		switch msg {
+		case "falls asleep":
+			if asleep != nil {
+				return nil, fmt.Errorf("%w: guard #%d fell asleep twice (%q)", errGuardShiftSleep, g.guardID, line)
+			}
+			asleep = &t
+		case "wakes up":
+			if asleep == nil {
+				return nil, fmt.Errorf("%w: guard #%d woke up without falling asleep (%q)", errGuardShiftSleep, g.guardID, line)
+			}
+			g.sleepPeriods = append(g.sleepPeriods, period{*asleep, t.Sub(*asleep)})
+			asleep = nil
+		default:
+			return nil, fmt.Errorf("%w: %q", errGuardShiftLine, line)
+		}
+	}
+
+	// nobody wakes them up, so they sleep through the end of the shift
+	if asleep != nil {
+		g.sleepPeriods = append(g.sleepPeriods, period{*asleep, end.Sub(*asleep)})
+	}
+
+	return &g, nil
+}
+
 func readerToGuardShifts(r io.Reader) ([]guardShift, error) {
+	var shifts []guardShift
+
 	scanner := bufio.NewScanner(r)
 	scanner.Split(scanGuardShift)
 	for scanner.Scan() {
-		log.Printf("------")
-		log.Printf(scanner.Text())
-		log.Printf("------")
+		g, err := newGuardShift(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		shifts = append(shifts, *g)
 	}
 	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	return nil, fmt.Errorf("Not implemented")
+
+	return shifts, nil
 }
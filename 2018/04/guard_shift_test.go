@@ -2,6 +2,8 @@ package main
 
 import (
 	"bufio"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -76,3 +78,111 @@ func TestGuardShiftScanner(t *testing.T) {
 		t.Fatalf("Expected (%+v) to match (%+v)", actualOutputs, tc.output)
 	}
 }
+
+func TestNewGuardShift(t *testing.T) {
+	testCases := []struct {
+		name         string
+		input        string
+		guardID      int
+		shiftMinutes float64
+		sleepPeriods []string
+		err          error
+	}{
+		{
+			"Begins Before Midnight",
+			`[1518-11-01 23:58] Guard #99 begins shift
+[1518-11-02 00:40] falls asleep
+[1518-11-02 00:50] wakes up
+`,
+			99,
+			62,
+			[]string{"00:40 10m0s"},
+			nil,
+		},
+		{
+			"Two Naps",
+			`[1518-11-01 00:00] Guard #10 begins shift
+[1518-11-01 00:05] falls asleep
+[1518-11-01 00:25] wakes up
+[1518-11-01 00:30] falls asleep
+[1518-11-01 00:55] wakes up`,
+			10,
+			60,
+			[]string{"00:05 20m0s", "00:30 25m0s"},
+			nil,
+		},
+		{
+			"Never Sleeps",
+			"[1518-11-01 00:02] Guard #7 begins shift\n",
+			7,
+			58,
+			[]string{},
+			nil,
+		},
+		{
+			"Wakes Without Sleeping",
+			`[1518-11-01 00:00] Guard #10 begins shift
+[1518-11-01 00:25] wakes up
+`,
+			0,
+			0,
+			nil,
+			errGuardShiftSleep,
+		},
+		{
+			"Falls Asleep Twice",
+			`[1518-11-01 00:00] Guard #10 begins shift
+[1518-11-01 00:05] falls asleep
+[1518-11-01 00:25] falls asleep
+`,
+			0,
+			0,
+			nil,
+			errGuardShiftSleep,
+		},
+		{
+			"Not A Shift",
+			"[1518-11-01 00:05] falls asleep\n",
+			0,
+			0,
+			nil,
+			errGuardShiftScan,
+		},
+		{
+			"Garbage",
+			`[1518-11-01 00:00] Guard #10 begins shift
+[1518-11-01 00:05] sneezes
+`,
+			0,
+			0,
+			nil,
+			errGuardShiftLine,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := newGuardShift(tc.input)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected %v but got %v", tc.err, err)
+			} else if err != nil {
+				return
+			}
+
+			if g.guardID != tc.guardID {
+				t.Errorf("Expected guard #%d, got #%d", tc.guardID, g.guardID)
+			}
+			if g.shift.duration.Minutes() != tc.shiftMinutes {
+				t.Errorf("Expected a %v minute shift, got %v", tc.shiftMinutes, g.shift.duration)
+			}
+
+			sleeps := []string{}
+			for _, p := range g.sleepPeriods {
+				sleeps = append(sleeps, fmt.Sprintf("%s %s", p.start.Format("15:04"), p.duration))
+			}
+			if !stringSliceEquals(sleeps, tc.sleepPeriods) {
+				t.Errorf("Expected sleeps (%+v), got (%+v)", tc.sleepPeriods, sleeps)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"bufio"
+	"flag"
+	"fmt"
 	"io"
 	"log"
 	"os"
@@ -29,10 +31,13 @@ func readerToSortedNewlines(r io.Reader) ([]string, error) {
 }
 
 func main() {
+	render := flag.String("render", "", "also draw the shifts and a heatmap of them: text or svg")
+	flag.Parse()
+
 	var f *os.File
-	if len(os.Args) == 2 {
+	if flag.NArg() == 1 {
 		var err error
-		f, err = os.Open(os.Args[1])
+		f, err = os.Open(flag.Arg(0))
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -51,10 +56,43 @@ func main() {
 		log.Fatal(err)
 	}
 
-	shifts, err := readerToGuardShifts(strings.NewReader(strings.Join(rows, "\n")))
+	shifts, err := readerToGuardShifts(strings.NewReader(strings.Join(rows, "\n") + "\n"))
 	if err != nil {
 		log.Fatal(err)
 	}
-	for _ = range shifts {
+
+	h := newSleepHistogram(shifts)
+
+	switch *render {
+	case "":
+	case "text":
+		if err := renderTimeline(os.Stdout, shifts); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println()
+		if err := renderHeatmap(os.Stdout, h); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println()
+	case "svg":
+		// the svg gets stdout to itself so it can be piped straight into a file
+		if err := renderSVG(os.Stdout, shifts, h); err != nil {
+			log.Fatal(err)
+		}
+		return
+	default:
+		log.Fatalf("Unknown -render %q, expected text or svg", *render)
+	}
+
+	guardID, minute, err := h.strategyOne()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("p1: %d\n", guardID*minute)
+
+	guardID, minute, err = h.strategyTwo()
+	if err != nil {
+		log.Fatal(err)
 	}
+	fmt.Printf("p2: %d\n", guardID*minute)
 }
@@ -0,0 +1,81 @@
+package main
+
+import "errors"
+
+var errNoSleep = errors.New("Nobody ever fell asleep")
+
+// minuteCounts is how many times a guard was asleep during each minute of the midnight hour
+type minuteCounts [60]int
+
+func (m minuteCounts) total() int {
+	var sum int
+	for _, c := range m {
+		sum += c
+	}
+	return sum
+}
+
+// sleepiest is the minute the guard was asleep most often
+// ties go to the earliest minute
+func (m minuteCounts) sleepiest() (minute int, count int) {
+	for i, c := range m {
+		if c > count {
+			minute, count = i, c
+		}
+	}
+	return minute, count
+}
+
+// sleepHistogram maps each guardID to their minute-by-minute sleep counts
+type sleepHistogram map[int]*minuteCounts
+
+func newSleepHistogram(shifts []guardShift) sleepHistogram {
+	h := sleepHistogram{}
+	for _, s := range shifts {
+		if _, ok := h[s.guardID]; !ok {
+			h[s.guardID] = &minuteCounts{}
+		}
+
+		for m, asleep := range s.asleep() {
+			if asleep {
+				h[s.guardID][m]++
+			}
+		}
+	}
+	return h
+}
+
+// strategyOne finds the guard that has the most minutes asleep
+// and the minute they're most often asleep
+// ties go to the lowest guardID
+func (h sleepHistogram) strategyOne() (guardID int, minute int, err error) {
+	best := 0
+	for id, counts := range h {
+		if total := counts.total(); total > best || (total == best && total > 0 && id < guardID) {
+			best, guardID = total, id
+		}
+	}
+	if best == 0 {
+		return 0, 0, errNoSleep
+	}
+
+	minute, _ = h[guardID].sleepiest()
+	return guardID, minute, nil
+}
+
+// strategyTwo finds the guard most frequently asleep on the same minute
+// ties go to the lowest guardID
+func (h sleepHistogram) strategyTwo() (guardID int, minute int, err error) {
+	best := 0
+	for id, counts := range h {
+		m, count := counts.sleepiest()
+		if count > best || (count == best && count > 0 && id < guardID) {
+			best, guardID, minute = count, id, m
+		}
+	}
+	if best == 0 {
+		return 0, 0, errNoSleep
+	}
+
+	return guardID, minute, nil
+}
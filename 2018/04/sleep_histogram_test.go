@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// the sample from the puzzle, already sorted
+const sampleRecords = `[1518-11-01 00:00] Guard #10 begins shift
+[1518-11-01 00:05] falls asleep
+[1518-11-01 00:25] wakes up
+[1518-11-01 00:30] falls asleep
+[1518-11-01 00:55] wakes up
+[1518-11-01 23:58] Guard #99 begins shift
+[1518-11-02 00:40] falls asleep
+[1518-11-02 00:50] wakes up
+[1518-11-03 00:05] Guard #10 begins shift
+[1518-11-03 00:24] falls asleep
+[1518-11-03 00:29] wakes up
+[1518-11-04 00:02] Guard #99 begins shift
+[1518-11-04 00:36] falls asleep
+[1518-11-04 00:46] wakes up
+[1518-11-05 00:03] Guard #99 begins shift
+[1518-11-05 00:45] falls asleep
+[1518-11-05 00:55] wakes up
+`
+
+func sampleHistogram(t *testing.T) sleepHistogram {
+	t.Helper()
+	shifts, err := readerToGuardShifts(strings.NewReader(sampleRecords))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(shifts) != 5 {
+		t.Fatalf("Expected 5 shifts, got %d", len(shifts))
+	}
+	return newSleepHistogram(shifts)
+}
+
+func TestSleepHistogram(t *testing.T) {
+	h := sampleHistogram(t)
+
+	if len(h) != 2 {
+		t.Fatalf("Expected 2 guards, got %d", len(h))
+	}
+	if total := h[10].total(); total != 50 {
+		t.Errorf("Expected guard #10 to sleep 50 minutes, got %d", total)
+	}
+	if total := h[99].total(); total != 30 {
+		t.Errorf("Expected guard #99 to sleep 30 minutes, got %d", total)
+	}
+	if minute, count := h[99].sleepiest(); minute != 45 || count != 3 {
+		t.Errorf("Expected guard #99 to be asleep 3 times on minute 45, got %d times on minute %d", count, minute)
+	}
+}
+
+func TestStrategies(t *testing.T) {
+	h := sampleHistogram(t)
+
+	testCases := []struct {
+		name     string
+		strategy func() (int, int, error)
+		guardID  int
+		minute   int
+	}{
+		{"Strategy 1", h.strategyOne, 10, 24},
+		{"Strategy 2", h.strategyTwo, 99, 45},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			guardID, minute, err := tc.strategy()
+			if err != nil {
+				t.Fatal(err)
+			} else if guardID != tc.guardID || minute != tc.minute {
+				t.Errorf("Expected guard #%d minute %d, got guard #%d minute %d", tc.guardID, tc.minute, guardID, minute)
+			}
+		})
+	}
+}
+
+func TestStrategiesWithoutSleep(t *testing.T) {
+	h := newSleepHistogram([]guardShift{{guardID: 1}})
+
+	if _, _, err := h.strategyOne(); !errors.Is(err, errNoSleep) {
+		t.Errorf("Expected errNoSleep, got %v", err)
+	}
+	if _, _, err := h.strategyTwo(); !errors.Is(err, errNoSleep) {
+		t.Errorf("Expected errNoSleep, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// asleep marks each minute of the midnight hour the guard spent sleeping
+func (g guardShift) asleep() [60]bool {
+	var minutes [60]bool
+	for _, p := range g.sleepPeriods {
+		// only the midnight hour is observed, so Minute() is all we need
+		start := p.start.Minute()
+		for m := start; m < start+int(p.duration.Minutes()) && m < 60; m++ {
+			minutes[m] = true
+		}
+	}
+	return minutes
+}
+
+// day is the month-day the guard was on duty for
+// which is the day _after_ they showed up if they were early
+func (g guardShift) day() string {
+	return g.shift.start.Add(g.shift.duration).Format("01-02")
+}
+
+// the two rows of the puzzle's minute header, tens and then ones
+func minuteHeader(indent string) string {
+	var tens, ones []byte
+	for m := 0; m < 60; m++ {
+		tens = append(tens, byte('0'+m/10))
+		ones = append(ones, byte('0'+m%10))
+	}
+	return fmt.Sprintf("%s%s\n%s%s\n", indent, tens, indent, ones)
+}
+
+// renderTimeline draws each shift the way the puzzle does:
+//
+//	Date   ID   Minute
+//	            000000000011111111112222222222333333333344444444445555555555
+//	            012345678901234567890123456789012345678901234567890123456789
+//	11-01  #10  .....####################.....#########################.....
+//
+// shifts are drawn in the order they're given, so an unsorted input is easy to spot
+func renderTimeline(w io.Writer, shifts []guardShift) error {
+	idWidth := 2
+	for _, g := range shifts {
+		if l := len(fmt.Sprint(g.guardID)); l > idWidth {
+			idWidth = l
+		}
+	}
+	indent := fmt.Sprintf("%-*s", 5+2+1+idWidth+2, "")
+
+	if _, err := fmt.Fprintf(w, "%-5s  %-*s  Minute\n%s", "Date", idWidth+1, "ID", minuteHeader(indent)); err != nil {
+		return err
+	}
+
+	for _, g := range shifts {
+		row := make([]byte, 60)
+		for m, asleep := range g.asleep() {
+			row[m] = '.'
+			if asleep {
+				row[m] = '#'
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s  #%-*d  %s\n", g.day(), idWidth, g.guardID, row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// heatmapShades go from never asleep to asleep the most out of anyone on that minute
+const heatmapShades = " .:-=+*#%@"
+
+// guardIDs are the guards in the histogram, lowest first
+func (h sleepHistogram) guardIDs() []int {
+	ids := make([]int, 0, len(h))
+	for id := range h {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// max is the most times any guard was asleep on any minute
+func (h sleepHistogram) max() int {
+	var best int
+	for _, counts := range h {
+		if _, count := counts.sleepiest(); count > best {
+			best = count
+		}
+	}
+	return best
+}
+
+// renderHeatmap draws one row per guard with every minute shaded by how often they were asleep
+// the shading is relative to the sleepiest guard-minute, which is always drawn as '@'
+func renderHeatmap(w io.Writer, h sleepHistogram) error {
+	ids := h.guardIDs()
+	idWidth := 2
+	for _, id := range ids {
+		if l := len(fmt.Sprint(id)); l > idWidth {
+			idWidth = l
+		}
+	}
+	indent := fmt.Sprintf("%-*s", 1+idWidth+2, "")
+
+	if _, err := fmt.Fprintf(w, "%-*s  Minute\n%s", idWidth+1, "ID", minuteHeader(indent)); err != nil {
+		return err
+	}
+
+	best := h.max()
+	for _, id := range ids {
+		row := make([]byte, 60)
+		for m, count := range h[id] {
+			row[m] = heatmapShades[0]
+			if best > 0 {
+				row[m] = heatmapShades[count*(len(heatmapShades)-1)/best]
+			}
+		}
+		if _, err := fmt.Fprintf(w, "#%-*d  %s  %d\n", idWidth, id, row, h[id].total()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// sizes for the svg, in px
+const (
+	svgCell   = 10
+	svgLabel  = 120
+	svgHeader = 20
+)
+
+// renderSVG draws the same thing as renderTimeline and renderHeatmap
+// the timeline on top and the heatmap underneath it
+func renderSVG(w io.Writer, shifts []guardShift, h sleepHistogram) error {
+	ids := h.guardIDs()
+	heatmapTop := svgHeader + len(shifts)*svgCell + svgHeader
+	width := svgLabel + 60*svgCell
+	height := heatmapTop + len(ids)*svgCell
+
+	if _, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="monospace" font-size="%d">`+"\n", width, height, svgCell); err != nil {
+		return err
+	}
+
+	// minute markers every 5 minutes, over both sections
+	for m := 0; m < 60; m += 5 {
+		x := svgLabel + m*svgCell
+		if _, err := fmt.Fprintf(w, `<text x="%d" y="%d">%02d</text>`+"\n", x, svgHeader-5, m); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, `<text x="%d" y="%d">%02d</text>`+"\n", x, heatmapTop-5, m); err != nil {
+			return err
+		}
+	}
+
+	for i, g := range shifts {
+		y := svgHeader + i*svgCell
+		if _, err := fmt.Fprintf(w, `<text x="0" y="%d">%s #%d</text>`+"\n", y+svgCell-1, g.day(), g.guardID); err != nil {
+			return err
+		}
+		for m, asleep := range g.asleep() {
+			fill := "#eeeeee"
+			if asleep {
+				fill = "#333333"
+			}
+			if _, err := fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", svgLabel+m*svgCell, y, svgCell, svgCell, fill); err != nil {
+				return err
+			}
+		}
+	}
+
+	best := h.max()
+	for i, id := range ids {
+		y := heatmapTop + i*svgCell
+		if _, err := fmt.Fprintf(w, `<text x="0" y="%d">#%d (%d)</text>`+"\n", y+svgCell-1, id, h[id].total()); err != nil {
+			return err
+		}
+		for m, count := range h[id] {
+			opacity := 0.0
+			if best > 0 {
+				opacity = float64(count) / float64(best)
+			}
+			if _, err := fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="#cc0000" fill-opacity="%.2f"/>`+"\n", svgLabel+m*svgCell, y, svgCell, svgCell, opacity); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintln(w, "</svg>")
+	return err
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRenderTimeline(t *testing.T) {
+	shifts, err := readerToGuardShifts(strings.NewReader(sampleRecords))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// straight out of the puzzle
+	expected := `Date   ID   Minute
+            000000000011111111112222222222333333333344444444445555555555
+            012345678901234567890123456789012345678901234567890123456789
+11-01  #10  .....####################.....#########################.....
+11-02  #99  ........................................##########..........
+11-03  #10  ........................#####...............................
+11-04  #99  ....................................##########..............
+11-05  #99  .............................................##########.....
+`
+
+	var sb strings.Builder
+	if err := renderTimeline(&sb, shifts); err != nil {
+		t.Fatal(err)
+	} else if sb.String() != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, sb.String())
+	}
+}
+
+func TestRenderHeatmap(t *testing.T) {
+	h := sampleHistogram(t)
+
+	var sb strings.Builder
+	if err := renderHeatmap(&sb, h); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimRight(sb.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Expected 3 header lines and 2 guards, got\n%s", sb.String())
+	}
+	if !strings.HasPrefix(lines[3], "#10 ") || !strings.HasSuffix(lines[3], " 50") {
+		t.Errorf("Expected guard #10 with 50 minutes asleep first, got %q", lines[3])
+	}
+	// guard #99 was asleep on minute 45 three times, more than anyone else on any minute
+	if row := lines[4][5:65]; row[45] != '@' || strings.Count(row, "@") != 1 {
+		t.Errorf("Expected only minute 45 of guard #99 to be the hottest, got %q", row)
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	shifts, err := readerToGuardShifts(strings.NewReader(sampleRecords))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sb strings.Builder
+	if err := renderSVG(&sb, shifts, newSleepHistogram(shifts)); err != nil {
+		t.Fatal(err)
+	}
+
+	// make sure it's at least well formed, and count the cells
+	var rects int
+	d := xml.NewDecoder(strings.NewReader(sb.String()))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if se, ok := tok.(xml.StartElement); ok && se.Name.Local == "rect" {
+			rects++
+		}
+	}
+	if expected := (5 + 2) * 60; rects != expected {
+		t.Errorf("Expected %d cells, got %d", expected, rects)
+	}
+}
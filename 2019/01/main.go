@@ -2,37 +2,85 @@ package main
 
 import (
 	"bufio"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 )
 
+var ErrInvalidMass = errors.New("Module mass must be a positive whole number")
+
 type Module int // the mass of the Module
 
 func MassFromString(s string) (*Module, error) {
-	i, err := strconv.Atoi(s)
+	i, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%w, got %q: %v", ErrInvalidMass, s, err)
+	} else if i <= 0 {
+		return nil, fmt.Errorf("%w, got %d", ErrInvalidMass, i)
+	}
 
 	m := Module(i)
-
-	return &m, err
+	return &m, nil
 }
 
-func (m Module) Fuel() int {
-	fuel := int(m)/3 - 2
+// a FuelModel is how much fuel it takes to launch some mass
+type FuelModel func(mass int) int
+
+// NaiveFuel only counts the mass itself (part 1)
+// divide by three, round down, and subtract 2 (but never go negative)
+func NaiveFuel(mass int) int {
+	fuel := mass/3 - 2
 
 	if fuel < 0 {
 		return 0
 	}
+	return fuel
+}
+
+// RecursiveFuel also counts the fuel needed to launch the fuel (part 2)
+// and the fuel for _that_ fuel, until it's small enough not to need any
+func RecursiveFuel(mass int) int {
+	fuel := NaiveFuel(mass)
+	if fuel == 0 {
+		return 0
+	}
+	return fuel + RecursiveFuel(fuel)
+}
+
+func (m Module) Fuel(model FuelModel) int {
+	return model(int(m))
+}
 
-	// p1: return fuel
-	return fuel + Module(fuel).Fuel()
+// a FuelBreakdown is how much fuel one module needs
+type FuelBreakdown struct {
+	Module Module
+	Fuel   int
+}
+
+// Breakdown is the fuel each module needs under model, in order, and the total of all of them
+func Breakdown(modules []Module, model FuelModel) ([]FuelBreakdown, int) {
+	breakdown := make([]FuelBreakdown, 0, len(modules))
+	total := 0
+	for _, m := range modules {
+		fuel := m.Fuel(model)
+		breakdown = append(breakdown, FuelBreakdown{m, fuel})
+		total += fuel
+	}
+	return breakdown, total
 }
 
 func main() {
+	verbose := flag.Bool("v", false, "print the fuel for every module")
+	flag.Parse()
+
 	var f *os.File
-	if len(os.Args) == 2 {
+	if flag.NArg() == 1 {
 		var err error
-		f, err = os.Open(os.Args[1])
+		f, err = os.Open(flag.Arg(0))
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -46,19 +94,30 @@ func main() {
 		}
 	}()
 
-	totalFuel := 0
+	modules := []Module{}
 
 	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
+	for line := 1; scanner.Scan(); line++ {
 		module, err := MassFromString(scanner.Text())
 		if err != nil {
-			log.Fatal(err)
+			log.Fatalf("line %d: %v", line, err)
 		}
-		totalFuel += module.Fuel()
+		modules = append(modules, *module)
 	}
 	if err := scanner.Err(); err != nil {
 		log.Fatal(err)
 	}
 
-	log.Printf("Fuel required: %d", totalFuel)
+	naive, naiveTotal := Breakdown(modules, NaiveFuel)
+	recursive, recursiveTotal := Breakdown(modules, RecursiveFuel)
+
+	if *verbose {
+		fmt.Printf("%10s %10s %10s\n", "mass", "naive", "recursive")
+		for i := range modules {
+			fmt.Printf("%10d %10d %10d\n", modules[i], naive[i].Fuel, recursive[i].Fuel)
+		}
+	}
+
+	log.Printf("Fuel required: %d", naiveTotal)
+	log.Printf("Fuel required, including fuel for the fuel: %d", recursiveTotal)
 }
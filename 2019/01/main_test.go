@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMassFromString(t *testing.T) {
+	testCases := []struct {
+		input  string
+		output Module
+		err    error
+	}{
+		{"12", 12, nil},
+		{"100756", 100756, nil},
+		{" 14 ", 14, nil},
+		{"", 0, ErrInvalidMass},
+		{"abc", 0, ErrInvalidMass},
+		{"0", 0, ErrInvalidMass},
+		{"-12", 0, ErrInvalidMass},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%q", tc.input), func(t *testing.T) {
+			m, err := MassFromString(tc.input)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected %v but got %v", tc.err, err)
+			} else if err != nil {
+				if m != nil {
+					t.Fatalf("Did not expect a module alongside an error, got %d", *m)
+				}
+				return
+			}
+
+			if *m != tc.output {
+				t.Fatalf("Expected %d, got %d", tc.output, *m)
+			}
+		})
+	}
+}
+
+func TestFuel(t *testing.T) {
+	testCases := []struct {
+		mass      Module
+		naive     int
+		recursive int
+	}{
+		{12, 2, 2},
+		{14, 2, 2},
+		{1969, 654, 966},
+		{100756, 33583, 50346},
+		{1, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", tc.mass), func(t *testing.T) {
+			if fuel := tc.mass.Fuel(NaiveFuel); fuel != tc.naive {
+				t.Errorf("Expected naive fuel of %d, got %d", tc.naive, fuel)
+			}
+			if fuel := tc.mass.Fuel(RecursiveFuel); fuel != tc.recursive {
+				t.Errorf("Expected recursive fuel of %d, got %d", tc.recursive, fuel)
+			}
+		})
+	}
+}
+
+func TestBreakdown(t *testing.T) {
+	modules := []Module{12, 14, 1969, 100756}
+
+	testCases := []struct {
+		name  string
+		model FuelModel
+		fuel  []int
+		total int
+	}{
+		{"Naive", NaiveFuel, []int{2, 2, 654, 33583}, 34241},
+		{"Recursive", RecursiveFuel, []int{2, 2, 966, 50346}, 51316},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			breakdown, total := Breakdown(modules, tc.model)
+			if total != tc.total {
+				t.Errorf("Expected a total of %d, got %d", tc.total, total)
+			}
+			if len(breakdown) != len(modules) {
+				t.Fatalf("Expected %d modules, got %d", len(modules), len(breakdown))
+			}
+			for i, b := range breakdown {
+				if b.Module != modules[i] || b.Fuel != tc.fuel[i] {
+					t.Errorf("Expected module %d to be (%d, %d), got (%d, %d)", i, modules[i], tc.fuel[i], b.Module, b.Fuel)
+				}
+			}
+		})
+	}
+}
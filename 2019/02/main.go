@@ -1,14 +1,12 @@
 package main
 
 import (
-	"bufio"
 	"log"
 	"os"
 	"strconv"
-	"strings"
-	"unicode/utf8"
 
 	"gitlab.com/travisby/advent/2019/intcodevm"
+	"gitlab.com/travisby/advent/input"
 )
 
 func main() {
@@ -31,22 +29,8 @@ func main() {
 		}
 	}()
 
-	memory := []int{}
-
-	scanner := bufio.NewScanner(f)
-	scanner.Split(scanCommas)
-
-	for scanner.Scan() {
-		maybeInt := strings.Trim(scanner.Text(), "\n")
-
-		i, err := strconv.Atoi(maybeInt)
-		if err != nil {
-			log.Fatal(err)
-		}
-		memory = append(memory, i)
-	}
-
-	if err := scanner.Err(); err != nil {
+	memory, err := input.Ints(f)
+	if err != nil {
 		log.Fatal(err)
 	}
 
@@ -91,30 +75,3 @@ func main() {
 	}
 	log.Fatalf("Exhaustive search yielded no result for output=%d", reverseInputToSearchFor)
 }
-
-// shamelessly stolen from ScanWords, but with "," instead of " " as the delimiter
-func scanCommas(data []byte, atEOF bool) (advance int, token []byte, err error) {
-	// Skip leading spaces.
-	start := 0
-	for width := 0; start < len(data); start += width {
-		var r rune
-		r, width = utf8.DecodeRune(data[start:])
-		if r != ',' {
-			break
-		}
-	}
-	// Scan until space, marking end of word.
-	for width, i := 0, start; i < len(data); i += width {
-		var r rune
-		r, width = utf8.DecodeRune(data[i:])
-		if r == ',' {
-			return i + width, data[start:i], nil
-		}
-	}
-	// If we're at EOF, we have a final, non-empty, non-terminated word. Return it.
-	if atEOF && len(data) > start {
-		return len(data), data[start:], nil
-	}
-	// Request more data.
-	return start, nil, nil
-}
@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"math"
@@ -10,6 +11,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+
+	"gitlab.com/travisby/advent/geom"
 )
 
 type point struct {
@@ -21,52 +24,64 @@ type visit struct {
 	point
 	visitsAt int
 }
-type wire struct {
-	visits []visit
-}
 
-func (w *wire) up() {
-	last := w.visits[len(w.visits)-1]
-	next := last
-	next.y++
-	next.visitsAt++
-	w.visits = append(w.visits, next)
-}
-func (w *wire) down() {
-	last := w.visits[len(w.visits)-1]
-	next := last
-	next.y--
-	next.visitsAt++
-	w.visits = append(w.visits, next)
+// a segment is one straight run of wire, from one instruction
+type segment struct {
+	from, to point
+	// stepsAt is how far along the wire from is
+	stepsAt int
 }
-func (w *wire) left() {
-	last := w.visits[len(w.visits)-1]
-	next := last
-	next.x--
-	next.visitsAt++
-	w.visits = append(w.visits, next)
+
+// box is every point along the segment
+// (since it's a straight line, its bounding box is the segment itself)
+func (s segment) box() geom.Box {
+	return geom.NewBox(geom.Pt(s.from.x, s.from.y), geom.Pt(s.to.x, s.to.y))
 }
-func (w *wire) right() {
-	last := w.visits[len(w.visits)-1]
-	next := last
-	next.x++
-	next.visitsAt++
-	w.visits = append(w.visits, next)
+
+// stepsTo is how far along the wire p is, assuming p is on the segment
+func (s segment) stepsTo(p point) int {
+	return s.stepsAt + manhattanDistance(point{p.x - s.from.x, p.y - s.from.y})
 }
 
-func (w *wire) travel(i instruction) {
-	for _ = 0; i.x > 0; i.x-- {
-		w.right()
-	}
-	for _ = 0; i.x < 0; i.x++ {
-		w.left()
-	}
-	for _ = 0; i.y > 0; i.y-- {
-		w.up()
+// a wire is the segments it's made of, starting from the central port
+type wire []segment
+
+func newWire(instructions []instruction) wire {
+	w := make(wire, 0, len(instructions))
+
+	var at point
+	var steps int
+	for _, i := range instructions {
+		next := point{at.x + i.x, at.y + i.y}
+		w = append(w, segment{at, next, steps})
+		steps += manhattanDistance(point(i))
+		at = next
 	}
-	for _ = 0; i.y < 0; i.y++ {
-		w.down()
+	return w
+}
+
+// firstVisits is the fewest steps it takes w to reach every point it shares with o
+func (w wire) firstVisits(o wire) map[point]int {
+	visits := map[point]int{}
+	for _, s := range w {
+		for _, t := range o {
+			overlap, ok := s.box().Intersect(t.box())
+			if !ok {
+				continue
+			}
+
+			// wires running alongside each other share every point they overlap
+			for x := overlap.Min.X; x <= overlap.Max.X; x++ {
+				for y := overlap.Min.Y; y <= overlap.Max.Y; y++ {
+					p := point{x, y}
+					if steps, ok := visits[p]; !ok || s.stepsTo(p) < steps {
+						visits[p] = s.stepsTo(p)
+					}
+				}
+			}
+		}
 	}
+	return visits
 }
 
 var ErrUnknownInstruction = errors.New("Unknown instruction")
@@ -126,41 +141,57 @@ func right(x int) instruction {
 	return instruction(point{x, 0})
 }
 
-func getCrossings(a []instruction, b []instruction) []visit {
-	wire1Visits := accumulateVisits(a)
-	wire2Visits := accumulateVisits(b)
-
-	// create some maps to search for visits by index
-	wire1Map := make(map[point]int, len(wire1Visits))
-	wire2Map := make(map[point]int, len(wire2Visits))
+// getCrossings finds everywhere at least two of the wires cross
+// a wire never crosses itself, and each crossing's visitsAt is the combined steps
+// of every wire crossing there, counting only the first time each wire gets there
+// the central port, where every wire starts, is always the first crossing
+func getCrossings(instructions ...[]instruction) []visit {
+	wires := make([]wire, len(instructions))
+	for i := range instructions {
+		wires[i] = newWire(instructions[i])
+	}
 
-	for _, v := range wire1Visits {
-		// only save the first visitsAt per wire
-		if _, ok := wire1Map[v.point]; !ok {
-			wire1Map[v.point] = v.visitsAt
-		}
+	// every wire's first visit of everywhere it crosses another wire
+	steps := make([]map[point]int, len(wires))
+	for i := range steps {
+		steps[i] = map[point]int{}
 	}
-	for _, v := range wire2Visits {
-		// only save the first visitsAt per wire
-		if _, ok := wire2Map[v.point]; !ok {
-			wire2Map[v.point] = v.visitsAt
+	for i := range wires {
+		for j := i + 1; j < len(wires); j++ {
+			for p, s := range wires[i].firstVisits(wires[j]) {
+				steps[i][p] = s
+			}
+			for p, s := range wires[j].firstVisits(wires[i]) {
+				steps[j][p] = s
+			}
 		}
 	}
 
-	crossings := []visit{}
-	for k, v1 := range wire1Map {
-		if v2, ok := wire2Map[k]; ok {
-			crossings = append(crossings, visit{k, v1 + v2})
+	combined := map[point]int{}
+	for _, s := range steps {
+		for p, n := range s {
+			combined[p] += n
 		}
 	}
 
+	crossings := make([]visit, 0, len(combined))
+	for p, n := range combined {
+		crossings = append(crossings, visit{p, n})
+	}
+	// maps don't have an order, so give ties in the sorts below one
+	sort.Slice(crossings, func(i, j int) bool {
+		if crossings[i].x != crossings[j].x {
+			return crossings[i].x < crossings[j].x
+		}
+		return crossings[i].y < crossings[j].y
+	})
 	manhattanSort(crossings)
 
 	return crossings
 }
 
-func getClosestCrossingsDistance(a []instruction, b []instruction) (*int, error) {
-	crossings := getCrossings(a, b)
+func getClosestCrossingsDistance(wires ...[]instruction) (*int, error) {
+	crossings := getCrossings(wires...)
 	if len(crossings) < 2 {
 		return nil, fmt.Errorf("No crossings")
 	}
@@ -173,10 +204,13 @@ func getClosestCrossingsDistance(a []instruction, b []instruction) (*int, error)
 }
 
 func main() {
+	svgPath := flag.String("svg", "", "also draw the wires and their crossings to this svg")
+	flag.Parse()
+
 	var f *os.File
-	if len(os.Args) == 2 {
+	if flag.NArg() == 1 {
 		var err error
-		f, err = os.Open(os.Args[1])
+		f, err = os.Open(flag.Arg(0))
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -190,40 +224,38 @@ func main() {
 		}
 	}()
 
+	// one wire per line, and we need at least two of them to cross
+	wires := [][]instruction{}
 	scanner := bufio.NewScanner(f)
-	if !scanner.Scan() {
-		log.Fatalf("Expected instructions for wire 1")
-	}
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
-	}
-
-	// parse wire1
-	instructions0, err := parseInstructions(scanner.Text())
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	if !scanner.Scan() {
-		log.Fatalf("Expected instructions for wire 2")
+	for scanner.Scan() {
+		instructions, err := parseInstructions(scanner.Text())
+		if err != nil {
+			log.Fatalf("wire %d: %v", len(wires)+1, err)
+		}
+		wires = append(wires, instructions)
 	}
-
 	if err := scanner.Err(); err != nil {
 		log.Fatal(err)
 	}
-
-	// parse wire1
-	instructions1, err := parseInstructions(scanner.Text())
-	if err != nil {
-		log.Fatal(err)
+	if len(wires) < 2 {
+		log.Fatalf("Expected instructions for at least 2 wires, got %d", len(wires))
 	}
 
-	if scanner.Scan() {
-		log.Fatalf("Unexpected additional data")
+	if *svgPath != "" {
+		out, err := os.Create(*svgPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := renderSVG(out, wires, getCrossings(wires...)); err != nil {
+			log.Fatal(err)
+		}
+		if err := out.Close(); err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	// PART 1
-	distance, err := getClosestCrossingsDistance(instructions0, instructions1)
+	distance, err := getClosestCrossingsDistance(wires...)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -231,7 +263,7 @@ func main() {
 	// PART 1
 
 	// PART 2
-	crossings := getCrossings(instructions0, instructions1)
+	crossings := getCrossings(wires...)
 	if len(crossings) < 2 {
 		log.Fatalf("Not enough crossings")
 	}
@@ -245,7 +277,7 @@ func main() {
 }
 
 func timeSort(vs []visit) {
-	sort.Slice(
+	sort.SliceStable(
 		vs,
 		func(i, j int) bool {
 			return vs[i].visitsAt < vs[j].visitsAt
@@ -254,7 +286,7 @@ func timeSort(vs []visit) {
 }
 
 func manhattanSort(vs []visit) {
-	sort.Slice(
+	sort.SliceStable(
 		vs,
 		func(i, j int) bool {
 			return manhattanDistance(vs[i].point) < manhattanDistance(vs[j].point)
@@ -265,17 +297,3 @@ func manhattanSort(vs []visit) {
 func manhattanDistance(p point) int {
 	return int(math.Abs(float64(p.x-0))) + int(math.Abs(float64(p.y-0)))
 }
-
-// accumulate in this case is like python accumulate, or Haskell scan
-// we want to perform an application like a reduce/fold, but we also want the intermediate results
-// since we not only want to "get" to the end of the instructions, but we want to see each place we went to get there
-// to account for "R8" counting as only one point movement, we'll actually treat that as 8*R(1) for the sake of internal values
-func accumulateVisits(instructions []instruction) []visit {
-	wire := wire{[]visit{visit{}}}
-
-	for _, i := range instructions {
-		wire.travel(i)
-	}
-
-	return wire.visits
-}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"math/rand"
 	"testing"
 )
 
@@ -95,3 +96,144 @@ func pointsEquals(ps1, ps2 []point) bool {
 	}
 	return true
 }
+
+func TestFewestCombinedSteps(t *testing.T) {
+	testCases := []struct {
+		instructions [2][]instruction
+		steps        int
+	}{
+		{
+			[2][]instruction{
+				{right(8), up(5), left(5), down(3)},
+				{up(7), right(6), down(4), left(4)},
+			},
+			30,
+		},
+		{
+			[2][]instruction{
+				{right(75), down(30), right(83), up(83), left(12), down(49), right(71), up(7), left(72)},
+				{up(62), right(66), up(55), right(34), down(71), right(55), down(58), right(83)},
+			},
+			610,
+		},
+		{
+			[2][]instruction{
+				{right(98), up(47), right(26), down(63), right(33), up(87), left(62), down(20), right(33), up(53), right(51)},
+				{up(98), right(91), down(20), right(16), down(67), right(40), up(7), right(15), up(6), right(7)},
+			},
+			410,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%+v", tc.instructions), func(t *testing.T) {
+			crossings := getCrossings(tc.instructions[0], tc.instructions[1])[1:]
+			timeSort(crossings)
+			if crossings[0].visitsAt != tc.steps {
+				t.Errorf("expected (%d) got (%d)", tc.steps, crossings[0].visitsAt)
+			}
+		})
+	}
+}
+
+func TestGetCrossingsOverlapping(t *testing.T) {
+	// the wires run on top of each other from (2, 0) to (4, 0)
+	crossings := getCrossings(
+		[]instruction{right(4), up(1)},
+		[]instruction{up(1), right(2), down(1), right(5)},
+	)
+	expected := []visit{{point{0, 0}, 0}, {point{2, 0}, 2 + 4}, {point{3, 0}, 3 + 5}, {point{4, 0}, 4 + 6}}
+	if len(crossings) != len(expected) {
+		t.Fatalf("Expected (%+v), got (%+v)", expected, crossings)
+	}
+	for i := range crossings {
+		if crossings[i] != expected[i] {
+			t.Fatalf("Expected (%+v), got (%+v)", expected, crossings)
+		}
+	}
+}
+
+func TestGetCrossingsIgnoresSelf(t *testing.T) {
+	// the first wire crosses itself at (1, 0), and the second wire only ever crosses it at the port
+	crossings := getCrossings(
+		[]instruction{right(2), up(1), left(1), down(2)},
+		[]instruction{left(3)},
+	)
+	if len(crossings) != 1 || crossings[0] != (visit{}) {
+		t.Fatalf("Expected only the central port, got (%+v)", crossings)
+	}
+}
+
+// walks every wire one step at a time, like we used to
+func bruteForceCrossings(wires ...[]instruction) map[point]int {
+	firsts := make([]map[point]int, len(wires))
+	for i, instructions := range wires {
+		firsts[i] = map[point]int{{0, 0}: 0}
+		var at point
+		var steps int
+		for _, in := range instructions {
+			for n := manhattanDistance(point(in)); n > 0; n-- {
+				at.x += sign(in.x)
+				at.y += sign(in.y)
+				steps++
+				if _, ok := firsts[i][at]; !ok {
+					firsts[i][at] = steps
+				}
+			}
+		}
+	}
+
+	crossings := map[point]int{}
+	counts := map[point]int{}
+	for _, f := range firsts {
+		for p, steps := range f {
+			crossings[p] += steps
+			counts[p]++
+		}
+	}
+	for p, n := range counts {
+		if n < 2 {
+			delete(crossings, p)
+		}
+	}
+	return crossings
+}
+
+func sign(i int) int {
+	if i > 0 {
+		return 1
+	} else if i < 0 {
+		return -1
+	}
+	return 0
+}
+
+func TestGetCrossingsMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	directions := []func(int) instruction{up, down, left, right}
+
+	for i := 0; i < 200; i++ {
+		wires := make([][]instruction, 2+r.Intn(3))
+		for w := range wires {
+			wires[w] = make([]instruction, 1+r.Intn(10))
+			for j := range wires[w] {
+				wires[w][j] = directions[r.Intn(len(directions))](r.Intn(10))
+			}
+		}
+
+		expected := bruteForceCrossings(wires...)
+		crossings := getCrossings(wires...)
+		if len(crossings) != len(expected) {
+			t.Fatalf("%+v: expected %d crossings, got %d", wires, len(expected), len(crossings))
+		}
+		for _, c := range crossings {
+			if steps, ok := expected[c.point]; !ok || steps != c.visitsAt {
+				t.Fatalf("%+v: did not expect %+v", wires, c)
+			}
+		}
+		for j := 1; j < len(crossings); j++ {
+			if manhattanDistance(crossings[j-1].point) > manhattanDistance(crossings[j].point) {
+				t.Fatalf("Expected the crossings to be closest first, got %+v", crossings)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"gitlab.com/travisby/advent/geom"
+)
+
+// wireColors are used in order, going back to the start if there are more wires than colors
+var wireColors = []string{"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b"}
+
+// renderSVG draws every wire in its own color, starting from the central port
+// crossings are marked with a dot, and the closest one (by manhattanSort) is circled
+// crossings are expected to be straight out of getCrossings, central port and all
+//
+// the puzzle's up is the svg's down, so y is flipped to keep the picture the right way up
+func renderSVG(w io.Writer, wires [][]instruction, crossings []visit) error {
+	paths := make([][]point, len(wires))
+	bounds := geom.Box{}
+	for i, instructions := range wires {
+		paths[i] = []point{{0, 0}}
+		var at point
+		for _, in := range instructions {
+			at = point{at.x + in.x, at.y + in.y}
+			paths[i] = append(paths[i], at)
+			bounds = bounds.Extend(geom.Pt(at.x, -at.y))
+		}
+	}
+
+	// make everything a sensible size no matter how long the wires are
+	size := bounds.Width()
+	if bounds.Height() > size {
+		size = bounds.Height()
+	}
+	stroke := float64(size) / 500
+	if stroke < 0.1 {
+		stroke = 0.1
+	}
+	margin := 10 * stroke
+
+	if _, err := fmt.Fprintf(
+		w,
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="%g %g %g %g" width="800" height="800">`+"\n",
+		float64(bounds.Min.X)-margin, float64(bounds.Min.Y)-margin, float64(bounds.Width())+2*margin, float64(bounds.Height())+2*margin,
+	); err != nil {
+		return err
+	}
+
+	for i, path := range paths {
+		points := make([]string, 0, len(path))
+		for _, p := range path {
+			points = append(points, fmt.Sprintf("%d,%d", p.x, -p.y))
+		}
+		if _, err := fmt.Fprintf(
+			w,
+			`<polyline points="%s" fill="none" stroke="%s" stroke-width="%g" stroke-opacity="0.8"><title>wire %d</title></polyline>`+"\n",
+			strings.Join(points, " "), wireColors[i%len(wireColors)], stroke, i+1,
+		); err != nil {
+			return err
+		}
+	}
+
+	// the central port is always the first crossing, so skip it (and draw it on its own)
+	for i, c := range crossings {
+		if i == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(
+			w,
+			`<circle cx="%d" cy="%d" r="%g" fill="black"><title>(%d, %d) distance %d, steps %d</title></circle>`+"\n",
+			c.x, -c.y, 2*stroke, c.x, c.y, manhattanDistance(c.point), c.visitsAt,
+		); err != nil {
+			return err
+		}
+	}
+	if len(crossings) > 1 {
+		closest := crossings[1]
+		if _, err := fmt.Fprintf(
+			w,
+			`<circle cx="%d" cy="%d" r="%g" fill="none" stroke="red" stroke-width="%g"><title>closest: (%d, %d) distance %d</title></circle>`+"\n",
+			closest.x, -closest.y, 8*stroke, stroke, closest.x, closest.y, manhattanDistance(closest.point),
+		); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(
+		w,
+		`<rect x="%g" y="%g" width="%g" height="%g" fill="black"><title>central port</title></rect>`+"\n",
+		-3*stroke, -3*stroke, 6*stroke, 6*stroke,
+	); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintln(w, "</svg>")
+	return err
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRenderSVG(t *testing.T) {
+	wires := [][]instruction{
+		{right(8), up(5), left(5), down(3)},
+		{up(7), right(6), down(4), left(4)},
+	}
+
+	var sb strings.Builder
+	if err := renderSVG(&sb, wires, getCrossings(wires...)); err != nil {
+		t.Fatal(err)
+	}
+
+	var polylines, dots int
+	var closest, port bool
+	d := xml.NewDecoder(strings.NewReader(sb.String()))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		attrs := map[string]string{}
+		for _, a := range se.Attr {
+			attrs[a.Name.Local] = a.Value
+		}
+
+		switch se.Name.Local {
+		case "polyline":
+			polylines++
+			if i := polylines - 1; attrs["stroke"] != wireColors[i] {
+				t.Errorf("Expected wire %d to be %s, got %s", i+1, wireColors[i], attrs["stroke"])
+			}
+		case "circle":
+			if attrs["stroke"] == "red" {
+				closest = true
+				// (3, 3), but upside down
+				if attrs["cx"] != "3" || attrs["cy"] != "-3" {
+					t.Errorf("Expected (3, 3) to be the closest crossing, got (%s, %s)", attrs["cx"], attrs["cy"])
+				}
+			} else {
+				dots++
+			}
+		case "rect":
+			port = true
+		}
+	}
+
+	if polylines != 2 {
+		t.Errorf("Expected 2 wires, got %d", polylines)
+	}
+	if dots != 2 {
+		t.Errorf("Expected 2 crossings, got %d", dots)
+	}
+	if !closest {
+		t.Errorf("Expected the closest crossing to be highlighted")
+	}
+	if !port {
+		t.Errorf("Expected the central port to be drawn")
+	}
+}
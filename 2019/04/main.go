@@ -1,15 +1,19 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
-	"os"
 	"strconv"
 )
 
 type criterias struct {
-	intCriteria   []func(int) bool
 	strCriteria   []func(string) bool
 	digitCriteria []func([]int) bool
+	// prefixCriteria are digitCriteria that, if they fail for the first few digits,
+	// fail for the whole password too (like neverDecrease)
+	// which lets us skip every password starting with those digits
+	prefixCriteria []func([]int) bool
 }
 
 func (cs criterias) valid(s string) bool {
@@ -19,20 +23,7 @@ func (cs criterias) valid(s string) bool {
 		}
 	}
 
-	if len(cs.intCriteria) > 0 {
-		i, err := strconv.Atoi(s)
-		if err != nil {
-			return false
-		}
-
-		for _, c := range cs.intCriteria {
-			if !c(i) {
-				return false
-			}
-		}
-	}
-
-	if len(cs.digitCriteria) > 0 {
+	if len(cs.digitCriteria) > 0 || len(cs.prefixCriteria) > 0 {
 		digits := make([]int, 0, len(s))
 		for _, r := range s {
 			if r > '9' || r < '0' {
@@ -46,20 +37,122 @@ func (cs criterias) valid(s string) bool {
 				return false
 			}
 		}
+		for _, c := range cs.prefixCriteria {
+			if !c(digits) {
+				return false
+			}
+		}
 	}
 	return true
 }
 
-func numDigits(i int) func(string) bool {
-	return func(s string) bool {
-		return len(s) == i
+// each calls fn with every valid password in [min, max], smallest first
+//
+// rather than checking every number in the range, passwords are built up a digit at a time
+// and every password starting with a prefix that fails the prefixCriteria is skipped
+// with neverDecrease that's only a few thousand candidates for six digits, instead of a few hundred thousand
+func (cs criterias) each(min, max int, fn func(int)) {
+	if min < 0 {
+		min = 0
+	}
+	if max < min {
+		return
+	}
+
+	// each length is its own walk, so the first digit of anything but a single digit can't be 0
+	for length := len(strconv.Itoa(min)); length <= len(strconv.Itoa(max)); length++ {
+		lo, hi := pow10(length-1), pow10(length)-1
+		if length == 1 {
+			lo = 0
+		}
+		if min > lo {
+			lo = min
+		}
+		if max < hi {
+			hi = max
+		}
+		cs.walk(digitsOf(lo), digitsOf(hi), make([]int, 0, length), true, true, fn)
 	}
 }
 
-func withinRange(x, y int) func(int) bool {
-	return func(i int) bool {
-		// TODO is the inclusivity correct here?
-		return i >= x && i < y
+// walk tries every next digit for prefix, staying within lo and hi
+// atLo and atHi are whether prefix is the start of lo or hi,
+// in which case the next digit can't go below lo's (or above hi's)
+func (cs criterias) walk(lo, hi []int, prefix []int, atLo, atHi bool, fn func(int)) {
+	i := len(prefix)
+	if i == len(lo) {
+		n := 0
+		for _, d := range prefix {
+			n = n*10 + d
+		}
+		if cs.valid(strconv.Itoa(n)) {
+			fn(n)
+		}
+		return
+	}
+
+	first, last := 0, 9
+	if atLo {
+		first = lo[i]
+	}
+	if atHi {
+		last = hi[i]
+	}
+
+	for d := first; d <= last; d++ {
+		next := append(prefix, d)
+
+		ok := true
+		for _, c := range cs.prefixCriteria {
+			if !c(next) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			cs.walk(lo, hi, next, atLo && d == first, atHi && d == last, fn)
+		}
+	}
+}
+
+// count is how many valid passwords there are in [min, max]
+func (cs criterias) count(min, max int) int {
+	n := 0
+	cs.each(min, max, func(int) {
+		n++
+	})
+	return n
+}
+
+// list is every valid password in [min, max], smallest first
+func (cs criterias) list(min, max int) []int {
+	passwords := []int{}
+	cs.each(min, max, func(i int) {
+		passwords = append(passwords, i)
+	})
+	return passwords
+}
+
+func pow10(n int) int {
+	p := 1
+	for ; n > 0; n-- {
+		p *= 10
+	}
+	return p
+}
+
+func digitsOf(i int) []int {
+	s := strconv.Itoa(i)
+	digits := make([]int, len(s))
+	for j, r := range s {
+		digits[j] = int(r - '0')
+	}
+	return digits
+}
+
+func numDigits(i int) func(string) bool {
+	return func(s string) bool {
+		return len(s) == i
 	}
 }
 
@@ -106,40 +199,46 @@ func neverDecrease(n []int) bool {
 	return true
 }
 
+// the puzzle's rules, as a ruleSet
+const (
+	part1Rules = "len=6; nondecreasing; has-run>=2"
+	part2Rules = part1Rules + "; has-run==2"
+)
+
 func main() {
-	var min, max int
-	var err error
-	if len(os.Args) != 3 {
-		log.Fatalf("Expected arg1 to be the min, and arg2 to be the max")
-	} else if min, err = strconv.Atoi(os.Args[1]); err != nil {
-		log.Fatalf("Expected arg1 to be the int, got %v", os.Args[1])
-	} else if max, err = strconv.Atoi(os.Args[2]); err != nil {
-		log.Fatalf("Expected arg2 to be the int, got %v", os.Args[2])
-	}
+	rules := flag.String("rules", "", "count passwords matching these rules instead of the puzzle's (see ruleSet)")
+	list := flag.Bool("list", false, "print every matching password too")
+	flag.Parse()
 
-	c := criterias{
-		strCriteria:   []func(string) bool{numDigits(6)},
-		intCriteria:   []func(int) bool{withinRange(100000, 999999)},
-		digitCriteria: []func([]int) bool{adjacentDigitsSameness, neverDecrease},
+	parts := []string{part1Rules, part2Rules}
+	if *rules != "" {
+		parts = []string{*rules}
 	}
 
-	cCounter := 0
+	for _, text := range parts {
+		rs, err := parseRules(text)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-	for i := min; i < max; i++ {
-		if c.valid(strconv.Itoa(i)) {
-			cCounter++
+		// the range can come from the command line or the rules, but the command line wins
+		// (the rules' range is only a default, so it doesn't filter anything out)
+		min, max := rs.min, rs.max
+		if flag.NArg() == 2 {
+			if min, err = strconv.Atoi(flag.Arg(0)); err != nil {
+				log.Fatalf("Expected arg1 to be the int, got %v", flag.Arg(0))
+			} else if max, err = strconv.Atoi(flag.Arg(1)); err != nil {
+				log.Fatalf("Expected arg2 to be the int, got %v", flag.Arg(1))
+			}
+		} else if !rs.hasRange {
+			log.Fatalf("Expected arg1 to be the min, and arg2 to be the max (or a range= rule)")
 		}
-	}
-	// PART 1
-	log.Printf("Total password possibilities: %d", cCounter)
 
-	// PART 2
-	cCounter = 0
-	c.digitCriteria = append(c.digitCriteria, adjacentTwoDigitsSameness)
-	for i := min; i < max; i++ {
-		if c.valid(strconv.Itoa(i)) {
-			cCounter++
+		if *list {
+			for _, p := range rs.list(min, max) {
+				fmt.Println(p)
+			}
 		}
+		log.Printf("Total password possibilities: %d", rs.count(min, max))
 	}
-	log.Printf("Total password possibilities: %d", cCounter)
 }
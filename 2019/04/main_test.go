@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func intSliceEquals(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// checks every number in [min, max] one by one, the way we used to
+func bruteForce(c criterias, min, max int) []int {
+	passwords := []int{}
+	for i := min; i <= max; i++ {
+		if c.valid(strconv.Itoa(i)) {
+			passwords = append(passwords, i)
+		}
+	}
+	return passwords
+}
+
+func TestValid(t *testing.T) {
+	c := criterias{
+		strCriteria:    []func(string) bool{numDigits(6)},
+		digitCriteria:  []func([]int) bool{adjacentDigitsSameness},
+		prefixCriteria: []func([]int) bool{neverDecrease},
+	}
+
+	testCases := []struct {
+		input string
+		p1    bool
+		p2    bool
+	}{
+		{"111111", true, false},
+		{"223450", false, false},
+		{"123789", false, false},
+		{"112233", true, true},
+		{"123444", true, false},
+		{"111122", true, true},
+		{"11122", false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			if actual := c.valid(tc.input); actual != tc.p1 {
+				t.Errorf("Expected %t for part 1, got %t", tc.p1, actual)
+			}
+
+			p2 := c
+			p2.digitCriteria = append([]func([]int) bool{adjacentTwoDigitsSameness}, c.digitCriteria...)
+			if actual := p2.valid(tc.input); actual != tc.p2 {
+				t.Errorf("Expected %t for part 2, got %t", tc.p2, actual)
+			}
+		})
+	}
+}
+
+func TestCountMatchesBruteForce(t *testing.T) {
+	testCases := []struct {
+		name     string
+		c        criterias
+		min, max int
+	}{
+		{
+			"Part 1",
+			criterias{
+				strCriteria:    []func(string) bool{numDigits(6)},
+				digitCriteria:  []func([]int) bool{adjacentDigitsSameness},
+				prefixCriteria: []func([]int) bool{neverDecrease},
+			},
+			357253, 892942,
+		},
+		{
+			"Part 2",
+			criterias{
+				strCriteria:    []func(string) bool{numDigits(6)},
+				digitCriteria:  []func([]int) bool{adjacentDigitsSameness, adjacentTwoDigitsSameness},
+				prefixCriteria: []func([]int) bool{neverDecrease},
+			},
+			357253, 892942,
+		},
+		{
+			"Across Lengths",
+			criterias{
+				digitCriteria:  []func([]int) bool{adjacentTwoDigitsSameness},
+				prefixCriteria: []func([]int) bool{neverDecrease},
+			},
+			0, 123456,
+		},
+		{
+			"Without Pruning",
+			criterias{
+				digitCriteria: []func([]int) bool{adjacentDigitsSameness},
+			},
+			7, 7777,
+		},
+		{
+			"Exact Endpoints",
+			criterias{prefixCriteria: []func([]int) bool{neverDecrease}},
+			1122, 1299,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expected := bruteForce(tc.c, tc.min, tc.max)
+			if actual := tc.c.list(tc.min, tc.max); !intSliceEquals(actual, expected) {
+				t.Fatalf("Expected %d passwords, got %d", len(expected), len(actual))
+			}
+			if actual := tc.c.count(tc.min, tc.max); actual != len(expected) {
+				t.Fatalf("Expected %d, got %d", len(expected), actual)
+			}
+		})
+	}
+}
+
+func TestCountBeyondSixDigits(t *testing.T) {
+	c := criterias{
+		digitCriteria:  []func([]int) bool{adjacentTwoDigitsSameness},
+		prefixCriteria: []func([]int) bool{neverDecrease},
+	}
+
+	// every 12 digit number, which we could never check one at a time
+	count := c.count(100000000000, 999999999999)
+	if count <= 0 {
+		t.Fatalf("Expected some 12 digit passwords, got %d", count)
+	}
+
+	// and the first few should be the same ones a brute force finds
+	if actual, expected := c.list(100000000000, 111111112222), bruteForce(c, 111111110000, 111111112222); !intSliceEquals(actual, expected) {
+		t.Fatalf("Expected (%v), got (%v)", expected, actual)
+	}
+}
+
+func TestCountEmptyRange(t *testing.T) {
+	c := criterias{prefixCriteria: []func([]int) bool{neverDecrease}}
+	for _, r := range [][2]int{{10, 9}, {21, 21}} {
+		t.Run(fmt.Sprint(r), func(t *testing.T) {
+			if count := c.count(r[0], r[1]); count != 0 {
+				t.Fatalf("Expected no passwords, got %d", count)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var errUnknownRule = errors.New("Unknown rule")
+var errInvalidRule = errors.New("Invalid rule")
+
+// a ruleSet is criterias written out as text, e.g.
+//
+//	len=6; range=357253..892942; nondecreasing; has-run>=2; has-run==2
+//
+// the rules are:
+//
+//	len=N          the password is N digits long
+//	range=A..B     look for passwords between A and B (inclusive), unless told to look somewhere else
+//	nondecreasing  the digits never decrease from left to right
+//	has-run<op>N   some run of the same digit is <op> N long, where <op> is one of == >= <=
+//
+// and rules are separated by ';'
+type ruleSet struct {
+	criterias
+	// the range=A..B rule, if there was one
+	min, max int
+	hasRange bool
+}
+
+func parseRules(s string) (*ruleSet, error) {
+	var rs ruleSet
+
+	for _, rule := range strings.Split(s, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		switch {
+		case rule == "nondecreasing":
+			rs.prefixCriteria = append(rs.prefixCriteria, neverDecrease)
+		case strings.HasPrefix(rule, "len="):
+			n, err := strconv.Atoi(strings.TrimPrefix(rule, "len="))
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: %q needs a positive length", errInvalidRule, rule)
+			}
+			rs.strCriteria = append(rs.strCriteria, numDigits(n))
+		case strings.HasPrefix(rule, "range="):
+			if rs.hasRange {
+				return nil, fmt.Errorf("%w: %q is the second range", errInvalidRule, rule)
+			}
+			bounds := strings.Split(strings.TrimPrefix(rule, "range="), "..")
+			if len(bounds) != 2 {
+				return nil, fmt.Errorf("%w: %q should look like range=A..B", errInvalidRule, rule)
+			}
+			min, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q: %v", errInvalidRule, rule, err)
+			}
+			max, err := strconv.Atoi(bounds[1])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q: %v", errInvalidRule, rule, err)
+			} else if max < min {
+				return nil, fmt.Errorf("%w: %q ends before it starts", errInvalidRule, rule)
+			}
+			// this is only where to look by default, not a criteria,
+			// so a range from the command line can look somewhere else
+			rs.min, rs.max, rs.hasRange = min, max, true
+		case strings.HasPrefix(rule, "has-run"):
+			c, err := parseRunRule(rule)
+			if err != nil {
+				return nil, err
+			}
+			rs.digitCriteria = append(rs.digitCriteria, c)
+		default:
+			return nil, fmt.Errorf("%w: %q", errUnknownRule, rule)
+		}
+	}
+
+	return &rs, nil
+}
+
+func parseRunRule(rule string) (func([]int) bool, error) {
+	rest := strings.TrimPrefix(rule, "has-run")
+
+	var op string
+	for _, o := range []string{"==", ">=", "<="} {
+		if strings.HasPrefix(rest, o) {
+			op = o
+		}
+	}
+	if op == "" {
+		return nil, fmt.Errorf("%w: %q should compare with one of == >= <=", errInvalidRule, rule)
+	}
+
+	n, err := strconv.Atoi(strings.TrimPrefix(rest, op))
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("%w: %q needs a positive run length", errInvalidRule, rule)
+	}
+
+	switch op {
+	case "==":
+		return hasRun(func(l int) bool { return l == n }), nil
+	case ">=":
+		return hasRun(func(l int) bool { return l >= n }), nil
+	default:
+		return hasRun(func(l int) bool { return l <= n }), nil
+	}
+}
+
+// hasRun is whether any run of the same digit has a length that satisfies fn
+// e.g. hasRun(func(l int) bool { return l >= 2 }) is adjacentDigitsSameness
+func hasRun(fn func(int) bool) func([]int) bool {
+	return func(n []int) bool {
+		for i := 0; i < len(n); {
+			j := i
+			for j < len(n) && n[j] == n[i] {
+				j++
+			}
+			if fn(j - i) {
+				return true
+			}
+			i = j
+		}
+		return false
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+// the range is only where to look by default, so looking somewhere else isn't cut short by it
+func TestRangeRuleIsOnlyADefault(t *testing.T) {
+	rs, err := parseRules("range=100..200; has-run>=3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual := rs.list(rs.min, rs.max); !intSliceEquals(actual, []int{111}) {
+		t.Errorf("Expected [111] within the rules' range, got %v", actual)
+	}
+	if actual := rs.list(100, 333); !intSliceEquals(actual, []int{111, 222, 333}) {
+		t.Errorf("Expected [111 222 333] within 100..333, got %v", actual)
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	testCases := []struct {
+		rules  string
+		valid  []string
+		not    []string
+		hasMin int
+		hasMax int
+	}{
+		{part1Rules, []string{"111111", "122345"}, []string{"223450", "123789", "11111"}, 0, 0},
+		{part2Rules, []string{"112233", "111122"}, []string{"123444", "111111"}, 0, 0},
+		{"range=100..200; has-run>=3", []string{"111", "222"}, []string{"99", "199"}, 100, 200},
+		{"has-run<=1", []string{"1234", "1123"}, []string{"1111", "112233"}, 0, 0},
+		{" len=2 ;; nondecreasing ; ", []string{"12", "99"}, []string{"21", "123"}, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.rules, func(t *testing.T) {
+			rs, err := parseRules(tc.rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, s := range tc.valid {
+				if !rs.valid(s) {
+					t.Errorf("Expected %q to be valid", s)
+				}
+			}
+			for _, s := range tc.not {
+				if rs.valid(s) {
+					t.Errorf("Expected %q to be invalid", s)
+				}
+			}
+			if rs.hasRange != (tc.hasMax != 0) || rs.min != tc.hasMin || rs.max != tc.hasMax {
+				t.Errorf("Expected the range to be %d..%d, got %d..%d (%t)", tc.hasMin, tc.hasMax, rs.min, rs.max, rs.hasRange)
+			}
+		})
+	}
+}
+
+func TestParseRulesMatchesCriterias(t *testing.T) {
+	rs, err := parseRules("range=357253..892942; " + part2Rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := criterias{
+		strCriteria:    []func(string) bool{numDigits(6)},
+		digitCriteria:  []func([]int) bool{adjacentDigitsSameness, adjacentTwoDigitsSameness},
+		prefixCriteria: []func([]int) bool{neverDecrease},
+	}
+	if actual, expected := rs.count(rs.min, rs.max), c.count(357253, 892942); actual != expected {
+		t.Fatalf("Expected %d, got %d", expected, actual)
+	}
+}
+
+func TestParseRulesErrors(t *testing.T) {
+	testCases := []struct {
+		rules string
+		err   error
+	}{
+		{"sorted", errUnknownRule},
+		{"len=", errInvalidRule},
+		{"len=0", errInvalidRule},
+		{"range=5", errInvalidRule},
+		{"range=5..x", errInvalidRule},
+		{"range=9..5", errInvalidRule},
+		{"range=1..2; range=3..4", errInvalidRule},
+		{"has-run!=2", errInvalidRule},
+		{"has-run>=", errInvalidRule},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.rules, func(t *testing.T) {
+			if _, err := parseRules(tc.rules); !errors.Is(err, tc.err) {
+				t.Fatalf("Expected %v but got %v", tc.err, err)
+			}
+		})
+	}
+}
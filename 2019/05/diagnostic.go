@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gitlab.com/travisby/advent/2019/intcodevm"
+	"gitlab.com/travisby/advent/2019/intcodevm/program"
+)
+
+var ErrNoOutput = errors.New("The diagnostic program never output anything")
+var ErrTestFailed = errors.New("Diagnostic test failed")
+
+// how many of the instructions leading up to a failed test to report
+const failureContext = 3
+
+// a diagnostic is everything a run of the TEST diagnostic program output
+type diagnostic struct {
+	outputs []int
+	// ran[i] is every instruction run before outputs[i], ending with the output itself
+	ran [][]string
+}
+
+// outputs is an io.Writer collecting everything the VM outputs, one int per line
+type outputs struct {
+	partial []byte
+	ints    []int
+}
+
+func (o *outputs) Write(p []byte) (int, error) {
+	o.partial = append(o.partial, p...)
+	for {
+		i := strings.IndexByte(string(o.partial), '\n')
+		if i == -1 {
+			return len(p), nil
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(string(o.partial[:i])))
+		if err != nil {
+			return 0, err
+		}
+		o.ints = append(o.ints, n)
+		o.partial = o.partial[i+1:]
+	}
+}
+
+// runDiagnostic runs the program, giving it systemID as its only input
+func runDiagnostic(memory []int, systemID int) (*diagnostic, error) {
+	vm := intcodevm.New(len(memory))
+	if err := vm.Load(0, memory); err != nil {
+		return nil, err
+	}
+
+	var d diagnostic
+	var out outputs
+	var ran []string
+	vm.SetIn(strings.NewReader(fmt.Sprintf("%d\n", systemID)))
+	vm.SetOut(&out)
+	vm.SetTrace(func(i program.Instruction) {
+		ran = append(ran, i.String())
+		// it was an output, so that's the end of this test
+		if len(out.ints) > len(d.outputs) {
+			d.outputs = append(d.outputs, out.ints[len(d.outputs):]...)
+			d.ran = append(d.ran, ran)
+			ran = nil
+		}
+	})
+
+	if err := vm.Run(); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// code is the diagnostic code, as long as every test before it passed (output 0)
+// a failed test reports the instructions that ran just before it, since one of them is broken
+func (d diagnostic) code() (int, error) {
+	if len(d.outputs) == 0 {
+		return 0, ErrNoOutput
+	}
+
+	for i, o := range d.outputs[:len(d.outputs)-1] {
+		if o == 0 {
+			continue
+		}
+
+		// everything but the output itself
+		before := d.ran[i][:len(d.ran[i])-1]
+		if len(before) > failureContext {
+			before = before[len(before)-failureContext:]
+		}
+		return 0, fmt.Errorf("%w: test %d was off by %d, after running %s", ErrTestFailed, i+1, o, strings.Join(before, ", "))
+	}
+
+	return d.outputs[len(d.outputs)-1], nil
+}
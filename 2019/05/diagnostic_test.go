@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDiagnosticCode(t *testing.T) {
+	// from the README, outputs 999 if the input is below 8, 1000 if it's 8, and 1001 if it's above 8
+	larger := []int{
+		3, 21, 1008, 21, 8, 20, 1005, 20, 22, 107, 8, 21, 20, 1006, 20, 31,
+		1106, 0, 36, 98, 0, 0, 1002, 21, 125, 20, 4, 20, 1105, 1, 46, 104,
+		999, 1105, 1, 46, 1101, 1000, 1, 20, 4, 20, 1105, 1, 46, 98, 99,
+	}
+
+	testCases := []struct {
+		memory   []int
+		systemID int
+		code     int
+	}{
+		{[]int{3, 0, 4, 0, 99}, 42, 42},
+		{[]int{104, 0, 104, 0, 3, 9, 4, 9, 99, 0}, 7, 7},
+		{larger, 7, 999},
+		{larger, 8, 1000},
+		{larger, 9, 1001},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%v %d", tc.memory, tc.systemID), func(t *testing.T) {
+			d, err := runDiagnostic(tc.memory, tc.systemID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if code, err := d.code(); err != nil {
+				t.Fatal(err)
+			} else if code != tc.code {
+				t.Fatalf("Expected %d, got %d", tc.code, code)
+			}
+		})
+	}
+}
+
+func TestDiagnosticOutputs(t *testing.T) {
+	d, err := runDiagnostic([]int{104, 0, 1101, 1, 2, 13, 104, 0, 4, 13, 99, 0, 0, 0}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(d.outputs) != 3 || d.outputs[0] != 0 || d.outputs[1] != 0 || d.outputs[2] != 3 {
+		t.Fatalf("Expected outputs [0 0 3], got %v", d.outputs)
+	}
+	// the add shows up before the second test
+	if len(d.ran) != 3 || len(d.ran[1]) != 2 || !strings.HasPrefix(d.ran[1][0], "Add") {
+		t.Fatalf("Expected the add to be run before the second output, got %q", d.ran)
+	}
+}
+
+func TestDiagnosticFailures(t *testing.T) {
+	// the second test is off by 3, right after an add
+	d, err := runDiagnostic([]int{104, 0, 1101, 1, 2, 13, 104, 3, 104, 42, 99, 0, 0, 0}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = d.code()
+	if !errors.Is(err, ErrTestFailed) {
+		t.Fatalf("Expected ErrTestFailed, got %v", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "test 2 was off by 3") || !strings.Contains(msg, "Add{") {
+		t.Errorf("Expected the error to point at test 2 and the add before it, got %q", msg)
+	}
+
+	d, err = runDiagnostic([]int{99}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.code(); !errors.Is(err, ErrNoOutput) {
+		t.Errorf("Expected ErrNoOutput, got %v", err)
+	}
+}
@@ -1,26 +1,27 @@
 package main
 
 import (
-	"bufio"
+	"flag"
 	"fmt"
 	"log"
 	"os"
-	"strconv"
-	"strings"
-	"unicode/utf8"
 
-	"gitlab.com/travisby/advent/2019/intcodevm"
+	"gitlab.com/travisby/advent/input"
 )
 
 func main() {
-	// since we require input from stdin for running this program we cannot allow the program to come from stdin
-	if len(os.Args) != 2 {
-		log.Printf("Expected one argument, the program name")
-	}
+	systemID := flag.Int("id", 1, "the ID of the system to test (1 for the air conditioner, 5 for the thermal radiators)")
+	flag.Parse()
 
-	f, err := os.Open(os.Args[1])
-	if err != nil {
-		log.Fatal(err)
+	var f *os.File
+	if flag.NArg() == 1 {
+		var err error
+		f, err = os.Open(flag.Arg(0))
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		f = os.Stdin
 	}
 
 	defer func() {
@@ -29,57 +30,19 @@ func main() {
 		}
 	}()
 
-	memory := []int{}
-
-	scanner := bufio.NewScanner(f)
-	scanner.Split(scanCommas)
-
-	for scanner.Scan() {
-		i, err := strconv.Atoi(strings.Trim(scanner.Text(), "\n"))
-		if err != nil {
-			log.Fatal(err)
-		}
-		memory = append(memory, i)
-	}
-
-	if err := scanner.Err(); err != nil {
+	memory, err := input.Ints(f)
+	if err != nil {
 		log.Fatal(err)
 	}
 
-	virtualMachine := intcodevm.New(len(memory))
-
-	// PART 1
-	if err := virtualMachine.Load(0, memory); err != nil {
-		log.Fatal(err)
-	} else if err := virtualMachine.Run(); err != nil {
+	d, err := runDiagnostic(memory, *systemID)
+	if err != nil {
 		log.Fatal(err)
 	}
-	fmt.Println("")
-}
 
-// shamelessly stolen from ScanWords, but with "," instead of " " as the delimiter
-func scanCommas(data []byte, atEOF bool) (advance int, token []byte, err error) {
-	// Skip leading spaces.
-	start := 0
-	for width := 0; start < len(data); start += width {
-		var r rune
-		r, width = utf8.DecodeRune(data[start:])
-		if r != ',' {
-			break
-		}
-	}
-	// Scan until space, marking end of word.
-	for width, i := 0, start; i < len(data); i += width {
-		var r rune
-		r, width = utf8.DecodeRune(data[i:])
-		if r == ',' {
-			return i + width, data[start:i], nil
-		}
-	}
-	// If we're at EOF, we have a final, non-empty, non-terminated word. Return it.
-	if atEOF && len(data) > start {
-		return len(data), data[start:], nil
+	code, err := d.code()
+	if err != nil {
+		log.Fatal(err)
 	}
-	// Request more data.
-	return start, nil, nil
+	fmt.Println(code)
 }
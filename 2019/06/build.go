@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gitlab.com/travisby/advent/input"
+)
+
+var ErrInvalidOrbit = errors.New("Orbits should look like A)B")
+var ErrDuplicateOrbit = errors.New("Already orbiting something")
+
+// an orbit is one line of input, child orbits parent
+type orbit struct {
+	parent, child string
+}
+
+func parseOrbit(s string) (orbit, error) {
+	splits := strings.Split(s, ")")
+	if len(splits) != 2 || splits[0] == "" || splits[1] == "" {
+		return orbit{}, ErrInvalidOrbit
+	} else if splits[0] == splits[1] {
+		return orbit{}, fmt.Errorf("%w: %q can't orbit itself", ErrCycle, splits[0])
+	}
+	return orbit{splits[0], splits[1]}, nil
+}
+
+// buildOrbitalMap reads orbits in whatever order they come
+// the root is whatever is orbitted without orbiting anything itself,
+// and the map is built out from there so parents are always added before their children
+//
+// errors point at the lines responsible: the second definition of a duplicate,
+// where each of multiple roots is first mentioned, or the lines making up a cycle
+func buildOrbitalMap(r io.Reader) (orbitalMap, error) {
+	orbits, err := input.ParseLines(r, parseOrbit)
+	if err != nil {
+		return nil, err
+	}
+
+	// every child can only be defined once
+	definedOn := map[string]int{}
+	children := map[string][]string{}
+	for i, o := range orbits {
+		if prev, ok := definedOn[o.child]; ok {
+			return nil, &input.ParseError{
+				Unit: "line",
+				N:    i + 1,
+				Text: o.parent + ")" + o.child,
+				Err:  fmt.Errorf("%w: %q is already defined on line %d", ErrDuplicateOrbit, o.child, prev),
+			}
+		}
+		definedOn[o.child] = i + 1
+		children[o.parent] = append(children[o.parent], o.child)
+	}
+
+	// roots are orbitted but never orbit, we remember where we first saw them
+	roots := []string{}
+	mentionedOn := map[string]int{}
+	for i, o := range orbits {
+		if _, ok := definedOn[o.parent]; ok {
+			continue
+		}
+		if _, ok := mentionedOn[o.parent]; !ok {
+			mentionedOn[o.parent] = i + 1
+			roots = append(roots, o.parent)
+		}
+	}
+	if len(roots) == 0 {
+		return nil, ErrNoRoot
+	} else if len(roots) > 1 {
+		where := make([]string, 0, len(roots))
+		for _, root := range roots {
+			where = append(where, fmt.Sprintf("%q (line %d)", root, mentionedOn[root]))
+		}
+		return nil, fmt.Errorf("%w: %s", ErrMultipleRoots, strings.Join(where, ", "))
+	}
+
+	om := newOrbitalMap()
+	om.addCOM(roots[0])
+	queue := []string{roots[0]}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+
+		for _, child := range children[parent] {
+			if err := om.addOrbitByName(parent, child); err != nil {
+				return nil, err
+			}
+			queue = append(queue, child)
+		}
+	}
+
+	// anything we didn't get to is orbiting in a circle, never reaching the root
+	if len(om) != len(definedOn)+1 {
+		lines := []int{}
+		for child, line := range definedOn {
+			if _, ok := om[child]; !ok {
+				lines = append(lines, line)
+			}
+		}
+		sort.Ints(lines)
+		return nil, fmt.Errorf("%w: lines %v never reach %q", ErrCycle, lines, roots[0])
+	}
+
+	return om, nil
+}
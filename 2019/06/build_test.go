@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gitlab.com/travisby/advent/input"
+)
+
+func TestBuildOrbitalMap(t *testing.T) {
+	lines := strings.Split(sampleOrbits, "\n")
+	// every child shows up before its parent
+	reversed := make([]string, len(lines))
+	for i := range lines {
+		reversed[len(lines)-1-i] = lines[i]
+	}
+
+	for name, in := range map[string]string{"In Order": sampleOrbits, "Reversed": strings.Join(reversed, "\n")} {
+		t.Run(name, func(t *testing.T) {
+			om, err := buildOrbitalMap(strings.NewReader(in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(om) != 14 {
+				t.Errorf("Expected 14 orbitals, got %d", len(om))
+			}
+			if om["COM"].orbits != nil {
+				t.Errorf("Expected COM to be the root")
+			}
+			if om["YOU"].orbits != om["K"] {
+				t.Errorf("Expected YOU to orbit K")
+			}
+
+			if checksum, err := om.checksum(); err != nil {
+				t.Fatal(err)
+			} else if checksum != 54 {
+				t.Errorf("Expected a checksum of 54, got %d", checksum)
+			}
+			if transfers, err := om.minimumOrbitalTransfers("YOU", "SAN"); err != nil {
+				t.Fatal(err)
+			} else if *transfers != 4 {
+				t.Errorf("Expected 4 transfers, got %d", *transfers)
+			}
+		})
+	}
+}
+
+func TestBuildOrbitalMapInfersRoot(t *testing.T) {
+	om, err := buildOrbitalMap(strings.NewReader("B)C\nSUN)A\nA)B\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o, ok := om["SUN"]; !ok || o.orbits != nil {
+		t.Fatalf("Expected SUN to be the root")
+	}
+}
+
+func TestBuildOrbitalMapErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		err   error
+		line  int
+		msg   string
+	}{
+		{"Malformed", "COM)B\nB-C\n", ErrInvalidOrbit, 2, ""},
+		{"Empty Name", "COM)B\n)C\n", ErrInvalidOrbit, 2, ""},
+		{"Self", "COM)B\nB)B\n", ErrCycle, 2, ""},
+		{"Duplicate", "COM)B\nB)C\nCOM)C\n", ErrDuplicateOrbit, 3, "already defined on line 2"},
+		{"Repeated", "COM)B\nCOM)B\n", ErrDuplicateOrbit, 2, "already defined on line 1"},
+		{"Multiple Roots", "COM)B\nB)C\nMOC)D\n", ErrMultipleRoots, 0, `"COM" (line 1), "MOC" (line 3)`},
+		{"Cycle", "COM)B\nX)Y\nB)C\nY)Z\nZ)X\n", ErrCycle, 0, "lines [2 4 5]"},
+		{"Only A Cycle", "X)Y\nY)X\n", ErrNoRoot, 0, ""},
+		{"Empty", "", ErrNoRoot, 0, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := buildOrbitalMap(strings.NewReader(tc.input))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected %v but got %v", tc.err, err)
+			}
+
+			var pe *input.ParseError
+			if tc.line != 0 && (!errors.As(err, &pe) || pe.N != tc.line) {
+				t.Errorf("Expected an error on line %d, got %v", tc.line, err)
+			}
+			if !strings.Contains(err.Error(), tc.msg) {
+				t.Errorf("Expected %q in %q", tc.msg, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// path is every orbital on the way from a to b, including both of them
+// it goes up from a to whatever they both orbit, then back down to b
+func (idx *orbitIndex) path(a, b *orbital) []*orbital {
+	l := idx.lca(a, b)
+
+	var up []*orbital
+	for o := a; o != l; o = o.orbits {
+		up = append(up, o)
+	}
+	up = append(up, l)
+
+	var down []*orbital
+	for o := b; o != l; o = o.orbits {
+		down = append(down, o)
+	}
+	for i := len(down) - 1; i >= 0; i-- {
+		up = append(up, down[i])
+	}
+	return up
+}
+
+// transferPath is the path minimumOrbitalTransfers counts the hops of
+// from a, through everything a and b orbit on the way, to b
+// a and b themselves are the first/last hops that aren't counted
+func (idx *orbitIndex) transferPath(a, b *orbital) ([]*orbital, error) {
+	if a.orbits == nil || b.orbits == nil {
+		return nil, fmt.Errorf("%w: %q and %q both need to be orbiting something", ErrUnknownParent, a.name, b.name)
+	}
+	return append(append([]*orbital{a}, idx.path(a.orbits, b.orbits)...), b), nil
+}
+
+// children are what o is orbitted by, sorted by name so the exports are stable
+func (o *orbital) children() []*orbital {
+	children := append([]*orbital{}, o.orbittedBy...)
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].name < children[j].name
+	})
+	return children
+}
+
+// highlighted is the set of orbitals (and the orbits between them) along a path
+type highlighted struct {
+	orbitals map[*orbital]bool
+	// orbits are keyed by the one doing the orbiting
+	orbits map[*orbital]bool
+}
+
+func newHighlighted(path []*orbital) highlighted {
+	h := highlighted{map[*orbital]bool{}, map[*orbital]bool{}}
+	for i, o := range path {
+		h.orbitals[o] = true
+		if i == 0 {
+			continue
+		}
+		// the path goes up and then down, so either one could be orbiting the other
+		if prev := path[i-1]; prev.orbits == o {
+			h.orbits[prev] = true
+		} else {
+			h.orbits[o] = true
+		}
+	}
+	return h
+}
+
+// writeDOT draws the map as a Graphviz digraph, with an edge from each orbital to whatever orbits it
+// anything along path is drawn in red
+func (idx *orbitIndex) writeDOT(w io.Writer, path []*orbital) error {
+	h := newHighlighted(path)
+
+	if _, err := fmt.Fprintln(w, "digraph orbits {\n\trankdir=LR;\n\tnode [shape=circle];"); err != nil {
+		return err
+	}
+	for _, o := range path {
+		if _, err := fmt.Fprintf(w, "\t%q [color=red];\n", o.name); err != nil {
+			return err
+		}
+	}
+	if len(path) > 0 {
+		for _, o := range []*orbital{path[0], path[len(path)-1]} {
+			if _, err := fmt.Fprintf(w, "\t%q [style=filled, fillcolor=red];\n", o.name); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, o := range idx.orbitals {
+		for _, child := range o.children() {
+			attrs := ""
+			if h.orbits[child] {
+				attrs = " [color=red, penwidth=3]"
+			}
+			if _, err := fmt.Fprintf(w, "\t%q -> %q%s;\n", o.name, child.name, attrs); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+// writeTree draws the map as an indented list, starting from the root
+// anything along path is marked with a '*'
+//
+//	COM
+//	  B
+//	    C
+//	    *G
+func (idx *orbitIndex) writeTree(w io.Writer, path []*orbital) error {
+	h := newHighlighted(path)
+
+	type entry struct {
+		o     *orbital
+		depth int
+	}
+	stack := []entry{{idx.root, 0}}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		mark := ""
+		if h.orbitals[e.o] {
+			mark = "*"
+		}
+		if _, err := fmt.Fprintf(w, "%s%s%s\n", strings.Repeat("  ", e.depth), mark, e.o.name); err != nil {
+			return err
+		}
+
+		// backwards, so they come off of the stack in order
+		children := e.o.children()
+		for i := len(children) - 1; i >= 0; i-- {
+			stack = append(stack, entry{children[i], e.depth + 1})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func sampleTransferPath(t *testing.T) (*orbitIndex, []*orbital) {
+	t.Helper()
+	om := mapFromString(t, sampleOrbits)
+	idx, err := om.index()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := idx.transferPath(om["YOU"], om["SAN"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return idx, path
+}
+
+func TestTransferPath(t *testing.T) {
+	_, path := sampleTransferPath(t)
+
+	names := []string{}
+	for _, o := range path {
+		names = append(names, o.name)
+	}
+	if actual := strings.Join(names, " "); actual != "YOU K J E D I SAN" {
+		t.Fatalf("Expected \"YOU K J E D I SAN\", got %q", actual)
+	}
+
+	// less the first and last hops, it's the same as minimumOrbitalTransfers
+	if hops := len(path) - 1 - 2; hops != 4 {
+		t.Fatalf("Expected 4 transfers, got %d", hops)
+	}
+}
+
+func TestWriteTree(t *testing.T) {
+	idx, path := sampleTransferPath(t)
+
+	expected := `COM
+  B
+    C
+      *D
+        *E
+          F
+          *J
+            *K
+              L
+              *YOU
+        *I
+          *SAN
+    G
+      H
+`
+	var sb strings.Builder
+	if err := idx.writeTree(&sb, path); err != nil {
+		t.Fatal(err)
+	} else if sb.String() != expected {
+		t.Fatalf("Expected\n%s\ngot\n%s", expected, sb.String())
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	idx, path := sampleTransferPath(t)
+
+	var sb strings.Builder
+	if err := idx.writeDOT(&sb, path); err != nil {
+		t.Fatal(err)
+	}
+	dot := sb.String()
+
+	if !strings.HasPrefix(dot, "digraph orbits {") || !strings.HasSuffix(dot, "}\n") {
+		t.Fatalf("Expected a digraph, got\n%s", dot)
+	}
+	if edges := strings.Count(dot, " -> "); edges != 13 {
+		t.Errorf("Expected an edge for all 13 orbits, got %d", edges)
+	}
+	if red := strings.Count(dot, "penwidth=3"); red != 6 {
+		t.Errorf("Expected the 6 hops between YOU and SAN to be highlighted, got %d", red)
+	}
+	for _, edge := range []string{`"K" -> "YOU" [color=red`, `"D" -> "I" [color=red`, `"E" -> "J" [color=red`, `"COM" -> "B";`} {
+		if !strings.Contains(dot, edge) {
+			t.Errorf("Expected %s in\n%s", edge, dot)
+		}
+	}
+
+	sb.Reset()
+	if err := idx.writeDOT(&sb, nil); err != nil {
+		t.Fatal(err)
+	} else if strings.Contains(sb.String(), "red") {
+		t.Errorf("Did not expect anything to be highlighted without a path")
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrNoRoot = errors.New("Nothing is at the center of the orbits")
+var ErrMultipleRoots = errors.New("More than one thing is at the center of the orbits")
+var ErrCycle = errors.New("Orbits go around in a circle")
+
+// an orbitIndex is everything we need to answer questions about an orbitalMap quickly
+// it's built with one walk down from the root, so it's only good until the map changes
+//
+// ancestors are found with binary lifting: up[k][i] is the 2^k-th thing orbital i orbits
+// so the lowest common ancestor of any two orbitals is only log(depth) jumps away
+type orbitIndex struct {
+	root      *orbital
+	orbitals  []*orbital
+	positions map[*orbital]int
+	depth     []int
+	up        [][]int
+}
+
+// index validates the map, and builds its orbitIndex
+// there has to be exactly one root (like COM), and everything has to orbit its way back to it
+func (m orbitalMap) index() (*orbitIndex, error) {
+	roots := []string{}
+	for name, o := range m {
+		if o.orbits == nil {
+			roots = append(roots, name)
+		}
+	}
+	sort.Strings(roots)
+	if len(roots) == 0 && len(m) > 0 {
+		return nil, fmt.Errorf("%w, so they must go around in a circle", ErrNoRoot)
+	} else if len(roots) == 0 {
+		return nil, ErrNoRoot
+	} else if len(roots) > 1 {
+		return nil, fmt.Errorf("%w: %v", ErrMultipleRoots, roots)
+	}
+
+	idx := orbitIndex{root: m[roots[0]], positions: make(map[*orbital]int, len(m))}
+
+	// walk down from the root, parents always come before their children
+	// so the depth of a child is just one more than its parent's
+	parents := []int{}
+	stack := []*orbital{idx.root}
+	for len(stack) > 0 {
+		o := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if _, ok := idx.positions[o]; ok {
+			return nil, fmt.Errorf("%w: %q is reached twice", ErrCycle, o.name)
+		}
+
+		i := len(idx.orbitals)
+		idx.positions[o] = i
+		idx.orbitals = append(idx.orbitals, o)
+		if o == idx.root {
+			idx.depth = append(idx.depth, 0)
+			parents = append(parents, i)
+		} else {
+			p := idx.positions[o.orbits]
+			idx.depth = append(idx.depth, idx.depth[p]+1)
+			parents = append(parents, p)
+		}
+
+		for _, child := range o.orbittedBy {
+			if child.orbits != o {
+				return nil, fmt.Errorf("%w: %q is orbitted by %q, which orbits %q", ErrCycle, o.name, child.name, child.orbits.name)
+			}
+			stack = append(stack, child)
+		}
+	}
+
+	// anything we didn't reach is stuck going around in a circle of its own
+	if len(idx.orbitals) != len(m) {
+		stuck := []string{}
+		for name, o := range m {
+			if _, ok := idx.positions[o]; !ok {
+				stuck = append(stuck, name)
+			}
+		}
+		sort.Strings(stuck)
+		return nil, fmt.Errorf("%w: %v never reach %q", ErrCycle, stuck, idx.root.name)
+	}
+
+	idx.up = [][]int{parents}
+	for k := 1; 1<<k < len(idx.orbitals); k++ {
+		prev := idx.up[k-1]
+		next := make([]int, len(prev))
+		for i := range prev {
+			next[i] = prev[prev[i]]
+		}
+		idx.up = append(idx.up, next)
+	}
+
+	return &idx, nil
+}
+
+// checksum is the total number of direct and indirect orbits
+// which is just how deep everything is
+func (idx *orbitIndex) checksum() uint {
+	var checksum uint
+	for _, d := range idx.depth {
+		checksum += uint(d)
+	}
+	return checksum
+}
+
+// lca is the deepest orbital that both a and b orbit (directly or not)
+// a and b count as orbiting themselves, so if a orbits b, it's b
+func (idx *orbitIndex) lca(a, b *orbital) *orbital {
+	i, j := idx.positions[a], idx.positions[b]
+	if idx.depth[i] < idx.depth[j] {
+		i, j = j, i
+	}
+
+	// get them both to the same depth
+	for k := len(idx.up) - 1; k >= 0; k-- {
+		if idx.depth[i]-1<<k >= idx.depth[j] {
+			i = idx.up[k][i]
+		}
+	}
+	if i == j {
+		return idx.orbitals[i]
+	}
+
+	// then climb as far as we can without them meeting
+	for k := len(idx.up) - 1; k >= 0; k-- {
+		if idx.up[k][i] != idx.up[k][j] {
+			i, j = idx.up[k][i], idx.up[k][j]
+		}
+	}
+	return idx.orbitals[idx.up[0][i]]
+}
+
+// distance is how many orbits apart a and b are
+func (idx *orbitIndex) distance(a, b *orbital) uint {
+	l := idx.lca(a, b)
+	return uint(idx.depth[idx.positions[a]] + idx.depth[idx.positions[b]] - 2*idx.depth[idx.positions[l]])
+}
+
+// transfers is how many orbital transfers it takes to get a over to orbiting whatever b orbits
+func (idx *orbitIndex) transfers(a, b *orbital) (uint, error) {
+	if a.orbits == nil || b.orbits == nil {
+		return 0, fmt.Errorf("%w: %q and %q both need to be orbiting something", ErrUnknownParent, a.name, b.name)
+	}
+	return idx.distance(a.orbits, b.orbits), nil
+}
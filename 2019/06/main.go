@@ -1,12 +1,11 @@
 package main
 
 import (
-	"bufio"
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
-	"strings"
 )
 
 type orbital struct {
@@ -15,15 +14,6 @@ type orbital struct {
 	orbittedBy []*orbital
 }
 
-func (o *orbital) allOrbits() []*orbital {
-	var allOrbits []*orbital
-	for curPos := o; curPos.orbits != nil; curPos = curPos.orbits {
-		allOrbits = append(allOrbits, curPos)
-	}
-
-	return allOrbits
-}
-
 func (o *orbital) addOrbittedBy(n *orbital) {
 	if n.orbits == nil {
 		n.orbits = o
@@ -34,15 +24,13 @@ func (o *orbital) addOrbittedBy(n *orbital) {
 
 type orbitalMap map[string]*orbital
 
-func (m orbitalMap) checksum() uint {
-	// VERY inefficient
-	// we calculate the checksum for intermediaries many times over
-	var checksum uint
-	for _, v := range m {
-		checksum += uint(len(v.allOrbits()))
+// checksum is the total number of direct and indirect orbits
+func (m orbitalMap) checksum() (uint, error) {
+	idx, err := m.index()
+	if err != nil {
+		return 0, err
 	}
-
-	return checksum
+	return idx.checksum(), nil
 }
 
 var ErrUnknownParent = errors.New("Unknown orbit-ee")
@@ -82,23 +70,16 @@ func (m orbitalMap) minimumOrbitalTransfers(a, b string) (*uint, error) {
 		return nil, ErrUnknownParent
 	}
 
-	aParents := aOrbital.allOrbits()
-	bParents := bOrbital.allOrbits()
-
-	// find the deepest shared parent
-	// XXX: very inefficient as well
-	for i := range aParents {
-		for j := range bParents {
-			if aParents[i] == bParents[j] {
-				temp := uint(i + j)
-				// You for some reason don't actually count the first/last hops... confusingly
-				temp -= 2
-				return &temp, nil
-			}
-		}
+	idx, err := m.index()
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("Err")
+	transfers, err := idx.transfers(aOrbital, bOrbital)
+	if err != nil {
+		return nil, err
+	}
+	return &transfers, nil
 }
 
 func newOrbitalMap() orbitalMap {
@@ -106,10 +87,15 @@ func newOrbitalMap() orbitalMap {
 }
 
 func main() {
+	dotPath := flag.String("dot", "", "also write the map to this Graphviz DOT file")
+	tree := flag.Bool("tree", false, "print the map as a tree")
+	highlight := flag.Bool("highlight", true, "highlight the transfer path between YOU and SAN in the DOT and the tree")
+	flag.Parse()
+
 	var f *os.File
-	if len(os.Args) == 2 {
+	if flag.NArg() == 1 {
 		var err error
-		f, err = os.Open(os.Args[1])
+		f, err = os.Open(flag.Arg(0))
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -123,34 +109,60 @@ func main() {
 		}
 	}()
 
-	om := newOrbitalMap()
-
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		splits := strings.Split(scanner.Text(), ")")
-		if len(splits) != 2 {
-			log.Fatalf("Failed to parse orbital instruction")
-		}
+	om, err := buildOrbitalMap(f)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-		// create a new COM and retry
-		if _, ok := om.getOrbital(splits[0]); !ok {
-			om.addCOM(splits[0])
-		}
+	checksum, err := om.checksum()
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Part 1: %d", checksum)
 
-		if err := om.addOrbitByName(splits[0], splits[1]); err != nil {
+	// not every map has YOU and SAN in it, but they can still be exported
+	you, san := om["YOU"], om["SAN"]
+	if you != nil && san != nil {
+		p2, err := om.minimumOrbitalTransfers("YOU", "SAN")
+		if err != nil {
 			log.Fatal(err)
 		}
-	}
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+		log.Printf("Part 2: %d", *p2)
+	} else {
+		log.Print("Part 2: YOU and SAN aren't both in the map")
 	}
 
-	log.Printf("Part 1: %d", om.checksum())
+	if *dotPath == "" && !*tree {
+		return
+	}
 
-	p2, err := om.minimumOrbitalTransfers("YOU", "SAN")
+	idx, err := om.index()
 	if err != nil {
 		log.Fatal(err)
 	}
+	var path []*orbital
+	if *highlight && you != nil && san != nil {
+		if path, err = idx.transferPath(you, san); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *tree {
+		if err := idx.writeTree(os.Stdout, path); err != nil {
+			log.Fatal(err)
+		}
+	}
 
-	log.Printf("Part 2: %d", *p2)
+	if *dotPath != "" {
+		out, err := os.Create(*dotPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := idx.writeDOT(out, path); err != nil {
+			log.Fatal(err)
+		}
+		if err := out.Close(); err != nil {
+			log.Fatal(err)
+		}
+	}
 }
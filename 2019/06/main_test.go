@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const sampleOrbits = `COM)B
+B)C
+C)D
+D)E
+E)F
+B)G
+G)H
+D)I
+E)J
+J)K
+K)L
+K)YOU
+I)SAN`
+
+// builds a map by hand, skipping buildOrbitalMap's checks so we can make broken ones
+func mapFromString(t testing.TB, s string) orbitalMap {
+	t.Helper()
+	om := newOrbitalMap()
+	for _, line := range strings.Split(s, "\n") {
+		splits := strings.Split(line, ")")
+		if _, ok := om.getOrbital(splits[0]); !ok {
+			om.addCOM(splits[0])
+		}
+		if err := om.addOrbitByName(splits[0], splits[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return om
+}
+
+func TestChecksum(t *testing.T) {
+	// the sample, without YOU and SAN
+	om := mapFromString(t, strings.Join(strings.Split(sampleOrbits, "\n")[:11], "\n"))
+
+	if checksum, err := om.checksum(); err != nil {
+		t.Fatal(err)
+	} else if checksum != 42 {
+		t.Fatalf("Expected 42, got %d", checksum)
+	}
+}
+
+func TestMinimumOrbitalTransfers(t *testing.T) {
+	om := mapFromString(t, sampleOrbits)
+
+	testCases := []struct {
+		a, b      string
+		transfers uint
+	}{
+		{"YOU", "SAN", 4},
+		{"SAN", "YOU", 4},
+		{"YOU", "L", 0},
+		{"H", "F", 4},
+		{"B", "YOU", 6},
+		{"YOU", "YOU", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s %s", tc.a, tc.b), func(t *testing.T) {
+			transfers, err := om.minimumOrbitalTransfers(tc.a, tc.b)
+			if err != nil {
+				t.Fatal(err)
+			} else if *transfers != tc.transfers {
+				t.Fatalf("Expected %d, got %d", tc.transfers, *transfers)
+			}
+		})
+	}
+
+	if _, err := om.minimumOrbitalTransfers("COM", "YOU"); !errors.Is(err, ErrUnknownParent) {
+		t.Errorf("Expected COM not to be able to transfer anywhere, got %v", err)
+	}
+	if _, err := om.minimumOrbitalTransfers("YOU", "NOPE"); !errors.Is(err, ErrUnknownParent) {
+		t.Errorf("Expected ErrUnknownParent, got %v", err)
+	}
+}
+
+// compare against walking up from both orbitals, like we used to
+func TestLCAMatchesNaive(t *testing.T) {
+	om := mapFromString(t, sampleOrbits)
+	idx, err := om.index()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	naive := func(a, b *orbital) *orbital {
+		ancestors := map[*orbital]bool{}
+		for o := a; o != nil; o = o.orbits {
+			ancestors[o] = true
+		}
+		for o := b; o != nil; o = o.orbits {
+			if ancestors[o] {
+				return o
+			}
+		}
+		return nil
+	}
+
+	for _, a := range om {
+		for _, b := range om {
+			if expected, actual := naive(a, b), idx.lca(a, b); expected != actual {
+				t.Errorf("Expected lca(%s, %s) to be %s, got %s", a.name, b.name, expected.name, actual.name)
+			}
+		}
+	}
+}
+
+func TestIndexErrors(t *testing.T) {
+	t.Run("Multiple Roots", func(t *testing.T) {
+		om := mapFromString(t, "COM)B\nB)C\nMOC)D")
+		if _, err := om.checksum(); !errors.Is(err, ErrMultipleRoots) {
+			t.Fatalf("Expected ErrMultipleRoots, got %v", err)
+		}
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		om := mapFromString(t, "COM)B\nB)C\nX)Y\nY)Z")
+		// and now make X go around Z, which goes around Y, which goes around X
+		om["Z"].addOrbittedBy(om["X"])
+		if _, err := om.checksum(); !errors.Is(err, ErrCycle) {
+			t.Fatalf("Expected ErrCycle, got %v", err)
+		}
+	})
+
+	t.Run("Only A Cycle", func(t *testing.T) {
+		om := mapFromString(t, "X)Y")
+		om["Y"].addOrbittedBy(om["X"])
+		if _, err := om.checksum(); !errors.Is(err, ErrNoRoot) {
+			t.Fatalf("Expected ErrNoRoot, got %v", err)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if _, err := newOrbitalMap().checksum(); !errors.Is(err, ErrNoRoot) {
+			t.Fatalf("Expected ErrNoRoot, got %v", err)
+		}
+	})
+}
+
+func BenchmarkChecksum(b *testing.B) {
+	// a long chain is the worst case for walking up from every orbital
+	lines := []string{}
+	for i := 0; i < 10000; i++ {
+		lines = append(lines, fmt.Sprintf("N%d)N%d", i, i+1))
+	}
+	om := mapFromString(b, strings.Join(lines, "\n"))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := om.checksum(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,16 +1,25 @@
 package main
 
 import (
-	"bufio"
+	"flag"
+	"fmt"
 	"log"
 	"os"
+
+	"gitlab.com/travisby/advent/2019/08/sif"
+	"gitlab.com/travisby/advent/ocr"
 )
 
 func main() {
+	width := flag.Int("width", 25, "how many pixels wide the image is")
+	height := flag.Int("height", 6, "how many pixels tall the image is")
+	pngPath := flag.String("png", "", "also write the decoded image to this png")
+	flag.Parse()
+
 	var f *os.File
-	if len(os.Args) == 2 {
+	if flag.NArg() == 1 {
 		var err error
-		f, err = os.Open(os.Args[1])
+		f, err = os.Open(flag.Arg(0))
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -24,11 +33,37 @@ func main() {
 		}
 	}()
 
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		_ = scanner.Text()
+	img, err := sif.Decode(f, *width, *height)
+	if err != nil {
+		log.Fatal(err)
 	}
-	if err := scanner.Err(); err != nil {
+
+	checksum, err := img.Checksum()
+	if err != nil {
 		log.Fatal(err)
 	}
+	fmt.Printf("p1: %d\n", checksum)
+
+	decoded := img.Composite()
+	// if the letters can't be read, the image is still there to read by eye
+	message, err := ocr.Parse(decoded.String())
+	if err != nil {
+		log.Printf("Could not read the image: %v", err)
+		fmt.Printf("p2:\n%s", decoded)
+	} else {
+		fmt.Printf("p2: %s\n", message)
+	}
+
+	if *pngPath != "" {
+		out, err := os.Create(*pngPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := decoded.WritePNG(out, 10); err != nil {
+			log.Fatal(err)
+		}
+		if err := out.Close(); err != nil {
+			log.Fatal(err)
+		}
+	}
 }
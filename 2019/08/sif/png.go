@@ -0,0 +1,32 @@
+package sif
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// scaled blows each pixel of an image up into a scale*scale square
+// since a 25x6 png is pretty hard to look at
+type scaled struct {
+	image.Image
+	scale int
+}
+
+func (s scaled) Bounds() image.Rectangle {
+	b := s.Image.Bounds()
+	return image.Rectangle{b.Min.Mul(s.scale), b.Max.Mul(s.scale)}
+}
+
+func (s scaled) At(x, y int) color.Color {
+	return s.Image.At(x/s.scale, y/s.scale)
+}
+
+// WritePNG encodes the layer as a png, with every pixel scale*scale pixels big
+func (l *Layer) WritePNG(w io.Writer, scale int) error {
+	if scale < 1 {
+		scale = 1
+	}
+	return png.Encode(w, scaled{l, scale})
+}
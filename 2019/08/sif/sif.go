@@ -0,0 +1,168 @@
+// Package sif decodes images in the Elves' Space Image Format
+//
+// an image is a stream of digits, one per pixel, filling a layer row by row
+// and then moving on to the next layer once that one is full
+package sif
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+
+	"gitlab.com/travisby/advent/input"
+)
+
+var ErrInvalidPixel = errors.New("Pixels must be a digit")
+var ErrInvalidSize = errors.New("Images must be at least 1x1")
+var ErrNoLayers = errors.New("The image does not have any layers")
+
+// the colors a pixel can be
+const (
+	Black       uint8 = 0
+	White       uint8 = 1
+	Transparent uint8 = 2
+)
+
+// Layer is one width*height slice of an image
+// it's an image.Image too, so it can be handed to image/png (or anything else)
+type Layer struct {
+	Width, Height int
+	Pixels        []uint8
+}
+
+// Image is every layer of a transmitted image, front to back
+type Image struct {
+	Width, Height int
+	Layers        []*Layer
+}
+
+// Decode reads an image of the given size from r
+// newlines are ignored, but the image has to end on a layer boundary
+func Decode(r io.Reader, width, height int) (*Image, error) {
+	if width < 1 || height < 1 {
+		return nil, fmt.Errorf("%w: got %dx%d", ErrInvalidSize, width, height)
+	}
+
+	img := Image{Width: width, Height: height}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Split(input.ScanFixedWidth(width * height))
+	for scanner.Scan() {
+		l := Layer{width, height, make([]uint8, width*height)}
+		for i, c := range scanner.Bytes() {
+			if c < '0' || c > '9' {
+				return nil, fmt.Errorf("%w: layer %d has %q", ErrInvalidPixel, len(img.Layers)+1, c)
+			}
+			l.Pixels[i] = c - '0'
+		}
+		img.Layers = append(img.Layers, &l)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("layer %d: %w", len(img.Layers)+1, err)
+	}
+
+	if len(img.Layers) == 0 {
+		return nil, ErrNoLayers
+	}
+	return &img, nil
+}
+
+// Count is how many pixels in the layer are digit
+func (l *Layer) Count(digit uint8) int {
+	var n int
+	for _, p := range l.Pixels {
+		if p == digit {
+			n++
+		}
+	}
+	return n
+}
+
+// Pixel is the digit at (x, y)
+func (l *Layer) Pixel(x, y int) uint8 {
+	return l.Pixels[y*l.Width+x]
+}
+
+// Checksum finds the layer with the fewest 0 digits
+// and multiplies its number of 1 digits by its number of 2 digits
+// ties go to the frontmost layer
+func (img *Image) Checksum() (int, error) {
+	if len(img.Layers) == 0 {
+		return 0, ErrNoLayers
+	}
+
+	best := img.Layers[0]
+	for _, l := range img.Layers[1:] {
+		if l.Count(0) < best.Count(0) {
+			best = l
+		}
+	}
+	return best.Count(1) * best.Count(2), nil
+}
+
+// Composite stacks every layer on top of each other
+// each pixel is the frontmost one that isn't transparent
+// (and stays transparent if they all are)
+func (img *Image) Composite() *Layer {
+	l := Layer{img.Width, img.Height, make([]uint8, img.Width*img.Height)}
+	for i := range l.Pixels {
+		l.Pixels[i] = Transparent
+		for _, layer := range img.Layers {
+			if layer.Pixels[i] != Transparent {
+				l.Pixels[i] = layer.Pixels[i]
+				break
+			}
+		}
+	}
+	return &l
+}
+
+// String draws white as '#' and black as '.'
+// transparent pixels are ' ', and anything else is just its digit
+func (l *Layer) String() string {
+	b := make([]byte, 0, (l.Width+1)*l.Height)
+	for y := 0; y < l.Height; y++ {
+		for x := 0; x < l.Width; x++ {
+			switch p := l.Pixel(x, y); p {
+			case Black:
+				b = append(b, '.')
+			case White:
+				b = append(b, '#')
+			case Transparent:
+				b = append(b, ' ')
+			default:
+				b = append(b, '0'+p)
+			}
+		}
+		b = append(b, '\n')
+	}
+	return string(b)
+}
+
+// Palette is how each digit is drawn as an image.Image
+// anything past the end of it is drawn as transparent
+var Palette = color.Palette{color.Black, color.White, color.Transparent}
+
+// ColorModel is part of image.Image
+func (l *Layer) ColorModel() color.Model {
+	return Palette
+}
+
+// Bounds is part of image.Image
+func (l *Layer) Bounds() image.Rectangle {
+	return image.Rect(0, 0, l.Width, l.Height)
+}
+
+// At is part of image.Image
+func (l *Layer) At(x, y int) color.Color {
+	if !image.Pt(x, y).In(l.Bounds()) {
+		return color.Transparent
+	}
+	if p := l.Pixel(x, y); int(p) < len(Palette) {
+		return Palette[p]
+	}
+	return color.Transparent
+}
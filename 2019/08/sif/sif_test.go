@@ -0,0 +1,125 @@
+package sif
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"gitlab.com/travisby/advent/input"
+)
+
+func TestDecode(t *testing.T) {
+	img, err := Decode(strings.NewReader("123456789012\n"), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]uint8{{1, 2, 3, 4, 5, 6}, {7, 8, 9, 0, 1, 2}}
+	if len(img.Layers) != len(expected) {
+		t.Fatalf("Expected %d layers, got %d", len(expected), len(img.Layers))
+	}
+	for i, l := range img.Layers {
+		if string(l.Pixels) != string(expected[i]) {
+			t.Errorf("Expected layer %d to be %v, got %v", i+1, expected[i], l.Pixels)
+		}
+	}
+	if p := img.Layers[0].Pixel(2, 1); p != 6 {
+		t.Errorf("Expected (2, 1) of the first layer to be 6, got %d", p)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		width, height int
+		err           error
+	}{
+		{"Not A Digit", "1234a6", 3, 2, ErrInvalidPixel},
+		{"Short Layer", "1234567", 3, 2, input.ErrShortBlock},
+		{"Empty", "\n", 3, 2, ErrNoLayers},
+		{"No Size", "123456", 0, 2, ErrInvalidSize},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Decode(strings.NewReader(tc.input), tc.width, tc.height); !errors.Is(err, tc.err) {
+				t.Fatalf("Expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	// the second layer has no zeros, and two 1s and a 2
+	img, err := Decode(strings.NewReader("100000112345"), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if checksum, err := img.Checksum(); err != nil {
+		t.Fatal(err)
+	} else if checksum != 2 {
+		t.Errorf("Expected 2, got %d", checksum)
+	}
+}
+
+func TestComposite(t *testing.T) {
+	img, err := Decode(strings.NewReader("0222112222120000"), 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := img.Composite()
+	if string(l.Pixels) != string([]uint8{0, 1, 1, 0}) {
+		t.Errorf("Expected [0 1 1 0], got %v", l.Pixels)
+	}
+	if s := l.String(); s != ".#\n#.\n" {
+		t.Errorf("Expected %q, got %q", ".#\n#.\n", s)
+	}
+}
+
+func TestCompositeStaysTransparent(t *testing.T) {
+	img, err := Decode(strings.NewReader("2222"), 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s := img.Composite().String(); s != "  \n" {
+		t.Errorf("Expected %q, got %q", "  \n", s)
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	img, err := Decode(strings.NewReader("0222112222120000"), 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := img.Composite().WritePNG(&b, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := png.Decode(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := decoded.Bounds().Size(); size.X != 6 || size.Y != 6 {
+		t.Fatalf("Expected a 6x6 png, got %v", size)
+	}
+
+	white := color.GrayModel.Convert(color.White)
+	black := color.GrayModel.Convert(color.Black)
+	for _, tc := range []struct {
+		x, y  int
+		color color.Color
+	}{{0, 0, black}, {5, 0, white}, {2, 3, white}, {5, 5, black}} {
+		if c := color.GrayModel.Convert(decoded.At(tc.x, tc.y)); c != tc.color {
+			t.Errorf("Expected (%d, %d) to be %v, got %v", tc.x, tc.y, tc.color, c)
+		}
+	}
+}
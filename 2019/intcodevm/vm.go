@@ -16,6 +16,7 @@ type VM struct {
 	roMemory []int // the state of Load()'d data, ignoring what might happen after a Run().  This is a good copy of Programs
 	in       io.Reader
 	out      io.Writer
+	trace    func(program.Instruction)
 }
 
 // New creates a new Virtual Machine
@@ -65,6 +66,12 @@ func (v *VM) SetOut(w io.Writer) {
 	v.out = w
 }
 
+// SetTrace calls fn with every instruction the program runs, right after it runs
+// nil turns tracing back off
+func (v *VM) SetTrace(fn func(program.Instruction)) {
+	v.trace = fn
+}
+
 // Run the loaded program
 func (v *VM) Run() error {
 	p := program.NewScanner(v.memory, v.in, v.out)
@@ -72,6 +79,9 @@ func (v *VM) Run() error {
 		if err := p.Instruction().Apply(v.memory); err != nil {
 			return err
 		}
+		if v.trace != nil {
+			v.trace(p.Instruction())
+		}
 
 	}
 	return p.Err()
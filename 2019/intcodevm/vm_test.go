@@ -8,6 +8,8 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"gitlab.com/travisby/advent/2019/intcodevm/program"
 )
 
 func TestSimplePrograms(t *testing.T) {
@@ -84,6 +86,7 @@ func TestReset(t *testing.T) {
 		[]int{4, 5, 6},
 		nil,
 		nil,
+		nil,
 	}
 
 	if err := vm.Reset(); err != nil {
@@ -100,6 +103,7 @@ func TestSetNoun(t *testing.T) {
 		[]int{1, 2, 3},
 		nil,
 		nil,
+		nil,
 	}
 
 	if err := vm.SetNoun(9); err != nil {
@@ -132,7 +136,7 @@ func TestNounOverflow(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf("VM{%+v}.SetNoun(%d)", tc.memory, tc.noun), func(t *testing.T) {
-			vm := VM{tc.memory, tc.memory, nil, nil}
+			vm := VM{tc.memory, tc.memory, nil, nil, nil}
 			err := vm.SetNoun(tc.noun)
 			if tc.expectOverflow != (err == ErrOverflow) {
 				t.Errorf("Expected overflow (%t) and got (%+v)", tc.expectOverflow, (err == ErrOverflow))
@@ -158,7 +162,7 @@ func TestVerb(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf("VM{%+v}.SetVerb(%d)", tc.memory, tc.verb), func(t *testing.T) {
-			vm := VM{tc.memory, tc.memory, nil, nil}
+			vm := VM{tc.memory, tc.memory, nil, nil, nil}
 			err := vm.SetVerb(tc.verb)
 			if tc.expectOverflow != (err == ErrOverflow) {
 				t.Errorf("Expected overflow (%t) and got (%+v)", tc.expectOverflow, (err == ErrOverflow))
@@ -173,6 +177,7 @@ func TestSetVerb(t *testing.T) {
 		[]int{1, 2, 3},
 		nil,
 		nil,
+		nil,
 	}
 
 	if err := vm.SetVerb(9); err != nil {
@@ -225,3 +230,23 @@ func TestSetOutput(t *testing.T) {
 		t.Fatalf("Expected setting in to set in, got %+v", vm.out)
 	}
 }
+
+func TestSetTrace(t *testing.T) {
+	vm := New(9)
+	if err := vm.Load(0, []int{1, 1, 1, 4, 99, 5, 6, 0, 99}); err != nil {
+		t.Fatal(err)
+	}
+
+	traced := []string{}
+	vm.SetTrace(func(i program.Instruction) {
+		traced = append(traced, i.String())
+	})
+	if err := vm.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the add rewrites the halt at 4 into a multiply, which then writes to 0 before the real halt
+	if len(traced) != 2 || !strings.HasPrefix(traced[0], "Add") || !strings.HasPrefix(traced[1], "Multiply") {
+		t.Fatalf("Expected an add and then a multiply, got %q", traced)
+	}
+}
@@ -2,17 +2,34 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"strconv"
+
+	"gitlab.com/travisby/advent/input"
+	"gitlab.com/travisby/advent/ksum"
 )
 
+// product multiplies every expense in a solution together
+func product(expenses []int) int {
+	p := 1
+	for _, e := range expenses {
+		p *= e
+	}
+	return p
+}
+
 func main() {
+	target := flag.Int("target", 2020, "what the expenses have to add up to")
+	k := flag.Int("k", 0, "only look for this many expenses adding up to the target, rather than both parts")
+	flag.Parse()
+
 	var f *os.File
-	if len(os.Args) == 2 {
+	if flag.NArg() == 1 {
 		var err error
-		f, err = os.Open(os.Args[1])
+		f, err = os.Open(flag.Arg(0))
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -26,29 +43,24 @@ func main() {
 		}
 	}()
 
-	var is []int
+	expenses, err := input.ParseTokens(f, bufio.ScanWords, strconv.Atoi)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		i, err := strconv.Atoi(scanner.Text())
-		if err != nil {
-			log.Fatalf("%w: Expected number, got %q", err, scanner.Text())
+	if *k > 0 {
+		// every solution, not just the first
+		for _, solution := range ksum.Solve(expenses, *k, *target) {
+			fmt.Printf("%v: %d\n", solution, product(solution))
 		}
-		is = append(is, i)
-
-	}
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+		return
 	}
 
-	for i := range is {
-		for j := range is {
-			for k := range is {
-				if is[i]+is[j]+is[k] == 2020 {
-					fmt.Printf("%d\n", is[i]*is[j]*is[k])
-					return
-				}
-			}
+	for part, k := range []int{2, 3} {
+		solutions := ksum.Solve(expenses, k, *target)
+		if len(solutions) == 0 {
+			log.Fatalf("No %d expenses add up to %d", k, *target)
 		}
+		fmt.Printf("p%d: %d\n", part+1, product(solutions[0]))
 	}
 }
@@ -2,63 +2,87 @@ package main
 
 import (
 	"bufio"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"gitlab.com/travisby/advent/input"
 )
 
+var ErrInvalidPasswordLine = errors.New("Unknown password format")
+
 var PASSWORD_LINE_RE = regexp.MustCompile("^([0-9]+)-([0-9]+) ([a-zA-Z0-9]): ([a-zA-Z0-9]+)$")
 
+// a passwordLine is a password, and the policy that was in effect when it was chosen
+// what the policy's numbers mean is up to whichever Policy checks it
 type passwordLine struct {
+	line   int // where it was in the input, starting from 1
 	policy struct {
-		r       string
-		indices []int
+		lo, hi int
+		letter byte
 	}
 	password string
 }
 
-func (p passwordLine) isValid() bool {
-	substr := make([]byte, len(p.policy.indices))
-	for i, j := range p.policy.indices {
-		if j > len(p.password) {
-			return false
-		}
-		substr[i] = p.password[j-1]
-	}
-
-	return strings.Count(string(substr), p.policy.r) == 1
-}
-
 func newPasswordLine(s string) (*passwordLine, error) {
 	pass := PASSWORD_LINE_RE.FindStringSubmatch(s)
 	if len(pass) < 5 {
-		return nil, fmt.Errorf("Unknown password format %q", s)
+		return nil, ErrInvalidPasswordLine
 	}
 	p := passwordLine{}
 
-	// indices carrying password indices
-	for _, i := range []int{1, 2} {
-		j, err := strconv.Atoi(pass[i])
-		if err != nil {
-			return nil, fmt.Errorf("%w: cannot convert pass index: %q", err, pass[i])
-		}
-		p.policy.indices = append(p.policy.indices, j)
+	var err error
+	if p.policy.lo, err = strconv.Atoi(pass[1]); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPasswordLine, err)
+	}
+	if p.policy.hi, err = strconv.Atoi(pass[2]); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPasswordLine, err)
 	}
 
-	p.password = pass[len(pass)-1]
-	p.policy.r = pass[len(pass)-2]
+	p.policy.letter = pass[3][0]
+	p.password = pass[4]
 
 	return &p, nil
 }
 
+// parsePasswordLines reads every line of r, carrying on past the ones it can't make sense of
+// those come back as *input.ParseErrors, so they say which line they were
+func parsePasswordLines(r io.Reader) ([]passwordLine, []error) {
+	var lines []passwordLine
+	var errs []error
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		p, err := newPasswordLine(scanner.Text())
+		if err != nil {
+			errs = append(errs, &input.ParseError{Unit: "line", N: n, Text: scanner.Text(), Err: err})
+			continue
+		}
+		p.line = n
+		lines = append(lines, *p)
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return lines, errs
+}
+
 func main() {
+	policyName := flag.String("policy", "", "only check this policy ("+strings.Join(policyNames(), ", ")+"), rather than both parts")
+	verbose := flag.Bool("v", false, "print every password that doesn't follow the policy, and why")
+	flag.Parse()
+
 	var f *os.File
-	if len(os.Args) == 2 {
+	if flag.NArg() == 1 {
 		var err error
-		f, err = os.Open(os.Args[1])
+		f, err = os.Open(flag.Arg(0))
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -72,18 +96,38 @@ func main() {
 		}
 	}()
 
-	var count int
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		if p, err := newPasswordLine(scanner.Text()); err != nil {
+	lines, errs := parsePasswordLines(f)
+	for _, err := range errs {
+		log.Print(err)
+	}
+
+	// part 1 is the sled rental policy, part 2 is the toboggan one
+	names := []string{"count", "position"}
+	if *policyName != "" {
+		names = []string{*policyName}
+	}
+
+	for part, name := range names {
+		policy, err := lookupPolicy(name)
+		if err != nil {
 			log.Fatal(err)
-		} else if p.isValid() {
-			count++
 		}
-	}
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+
+		valid, violations := validate(lines, policy)
+		if *verbose {
+			for _, v := range violations {
+				log.Printf("%s: line %d: %v", name, v.line, v.err)
+			}
+		}
+
+		if *policyName != "" {
+			fmt.Printf("%d\n", valid)
+		} else {
+			fmt.Printf("p%d: %d\n", part+1, valid)
+		}
 	}
 
-	fmt.Printf("%d\n", count)
+	if len(errs) > 0 {
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var ErrPolicyViolation = errors.New("Password doesn't follow the policy")
+var ErrUnknownPolicy = errors.New("Unknown policy")
+
+// a Policy decides what a passwordLine's two numbers and letter mean
+type Policy interface {
+	// Check is nil if the password follows the policy, otherwise it says why it doesn't
+	Check(p passwordLine) error
+}
+
+// countPolicy is the sled rental place's policy:
+// the letter has to show up somewhere between lo and hi times
+type countPolicy struct{}
+
+func (countPolicy) Check(p passwordLine) error {
+	n := strings.Count(p.password, string(p.policy.letter))
+	if n < p.policy.lo || n > p.policy.hi {
+		return fmt.Errorf("%w: %c shows up %d times, expected %d-%d", ErrPolicyViolation, p.policy.letter, n, p.policy.lo, p.policy.hi)
+	}
+	return nil
+}
+
+// positionPolicy is the Official Toboggan Corporate Policy:
+// exactly one of the (1-indexed) positions lo and hi has to be the letter
+type positionPolicy struct{}
+
+func (positionPolicy) Check(p passwordLine) error {
+	var matches []int
+	for _, i := range []int{p.policy.lo, p.policy.hi} {
+		if i < 1 || i > len(p.password) {
+			return fmt.Errorf("%w: position %d is outside of a %d character password", ErrPolicyViolation, i, len(p.password))
+		}
+		if p.password[i-1] == p.policy.letter {
+			matches = append(matches, i)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("%w: neither position %d nor %d is %c", ErrPolicyViolation, p.policy.lo, p.policy.hi, p.policy.letter)
+	case 2:
+		return fmt.Errorf("%w: both position %d and %d are %c", ErrPolicyViolation, p.policy.lo, p.policy.hi, p.policy.letter)
+	}
+	return nil
+}
+
+// policies are every built in Policy, by the name to pick them with
+var policies = map[string]Policy{
+	"count":    countPolicy{},
+	"position": positionPolicy{},
+}
+
+func policyNames() []string {
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupPolicy(name string) (Policy, error) {
+	if p, ok := policies[name]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("%w %q, expected one of %s", ErrUnknownPolicy, name, strings.Join(policyNames(), ", "))
+}
+
+// a violation is a password that didn't follow the policy, and why
+type violation struct {
+	line int
+	err  error
+}
+
+// validate checks every password against policy
+// returning how many were valid, and every line that wasn't
+func validate(lines []passwordLine, policy Policy) (int, []violation) {
+	var valid int
+	violations := []violation{}
+	for _, p := range lines {
+		if err := policy.Check(p); err != nil {
+			violations = append(violations, violation{p.line, err})
+		} else {
+			valid++
+		}
+	}
+	return valid, violations
+}
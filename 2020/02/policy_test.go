@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gitlab.com/travisby/advent/input"
+)
+
+const sampleLines = `1-3 a: abcde
+1-3 b: cdefg
+2-9 c: ccccccccc`
+
+func TestParsePasswordLines(t *testing.T) {
+	lines, errs := parsePasswordLines(strings.NewReader("1-3 a: abcde\n1-3 b cdefg\n\n2-9 c: ccccccccc\n"))
+
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+	if lines[0].line != 1 || lines[0].policy.lo != 1 || lines[0].policy.hi != 3 || lines[0].policy.letter != 'a' || lines[0].password != "abcde" {
+		t.Errorf("Expected 1-3 a: abcde from line 1, got %+v", lines[0])
+	}
+	if lines[1].line != 4 || lines[1].password != "ccccccccc" {
+		t.Errorf("Expected ccccccccc from line 4, got %+v", lines[1])
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", errs)
+	}
+	for i, expected := range []int{2, 3} {
+		var pe *input.ParseError
+		if !errors.As(errs[i], &pe) || pe.N != expected {
+			t.Errorf("Expected an error for line %d, got %v", expected, errs[i])
+		} else if !errors.Is(errs[i], ErrInvalidPasswordLine) {
+			t.Errorf("Expected %v, got %v", ErrInvalidPasswordLine, errs[i])
+		}
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	lines, errs := parsePasswordLines(strings.NewReader(sampleLines))
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	for _, tc := range []struct {
+		policy   string
+		valid    int
+		violated []int
+	}{
+		{"count", 2, []int{2}},
+		{"position", 1, []int{2, 3}},
+	} {
+		policy, err := lookupPolicy(tc.policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		valid, violations := validate(lines, policy)
+		if valid != tc.valid {
+			t.Errorf("Expected %d valid passwords for %s, got %d", tc.valid, tc.policy, valid)
+		}
+		if len(violations) != len(tc.violated) {
+			t.Errorf("Expected lines %v to violate %s, got %v", tc.violated, tc.policy, violations)
+			continue
+		}
+		for i, v := range violations {
+			if v.line != tc.violated[i] {
+				t.Errorf("Expected line %d to violate %s, got %d", tc.violated[i], tc.policy, v.line)
+			}
+			if !errors.Is(v.err, ErrPolicyViolation) {
+				t.Errorf("Expected %v, got %v", ErrPolicyViolation, v.err)
+			}
+		}
+	}
+}
+
+func TestPositionPolicyOutOfRange(t *testing.T) {
+	p, err := newPasswordLine("1-9 a: abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := (positionPolicy{}).Check(*p); !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("Expected %v for a position past the end, got %v", ErrPolicyViolation, err)
+	}
+}
+
+func TestLookupPolicy(t *testing.T) {
+	if _, err := lookupPolicy("nope"); !errors.Is(err, ErrUnknownPolicy) {
+		t.Errorf("Expected %v, got %v", ErrUnknownPolicy, err)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -31,57 +32,38 @@ func newTreeLayer(s string) (*treeLayer, error) {
 	return &t, nil
 }
 
+// a treeMap is every layer of trees, top to bottom
+// it's never changed once it's read in, so any number of slopes can be explored at the same time
 type treeMap struct {
 	layers []treeLayer
-	curPos struct {
-		x int
-		y int
-	}
-	treesEncountered int
-}
-
-func (t *treeMap) reset() {
-	t.curPos.x = 0
-	t.curPos.y = 0
-	t.treesEncountered = 0
 }
 
 func (t *treeMap) addLayer(ts treeLayer) {
 	t.layers = append(t.layers, ts)
 }
 
-// traverse returns false if there's no where left to traverse
-// (AKA we've reached the bottom)
-// traversel L-R is infinite
-// traversal will return false and prevent overtravel
-func (t *treeMap) traverse(x, y int) (more bool) {
-	if t.curPos.y+y >= len(t.layers) {
-		return false
-	}
-
-	t.curPos.x += x
-	t.curPos.y += y
-
-	if t.layers[t.curPos.y].trees[t.curPos.x%len(t.layers[t.curPos.y].trees)] {
-		t.treesEncountered++
-	}
-
-	if t.curPos.y == len(t.layers)-1 {
-		return false
-	}
-
-	return true
-}
-
 func newTreeMap() *treeMap {
 	return &treeMap{}
 }
 
+// parseSlope reads a slope written as "right,down", e.g. "3,1"
+func parseSlope(s string) (slope, error) {
+	var sl slope
+	if n, err := fmt.Sscanf(s, "%d,%d", &sl.right, &sl.down); n != 2 || err != nil {
+		return slope{}, fmt.Errorf("%w: expected right,down, got %q", ErrTraversal, s)
+	}
+	return sl, nil
+}
+
 func main() {
+	render := flag.String("render", "", "draw the path down this slope (right,down) instead of solving")
+	search := flag.String("search", "", "find the slope with the fewest trees going at most this far (right,down) each step, instead of solving")
+	flag.Parse()
+
 	var f *os.File
-	if len(os.Args) == 2 {
+	if flag.NArg() == 1 {
 		var err error
-		f, err = os.Open(os.Args[1])
+		f, err = os.Open(flag.Arg(0))
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -110,37 +92,45 @@ func main() {
 		log.Fatal(err)
 	}
 
-	for more := true; more; more = treeMap.traverse(3, 1) {
-	}
-
-	log.Printf("Part One: %d", treeMap.treesEncountered)
-
-	// Part 2, get all of these plans and multiply their results
-	p2 := 1
-	treeMap.reset()
-	for more := true; more; more = treeMap.traverse(1, 1) {
+	if *render != "" {
+		s, err := parseSlope(*render)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := treeMap.renderPath(os.Stdout, s); err != nil {
+			log.Fatal(err)
+		}
+		return
 	}
-	p2 *= treeMap.treesEncountered
 
-	treeMap.reset()
-	for more := true; more; more = treeMap.traverse(3, 1) {
+	if *search != "" {
+		limit, err := parseSlope(*search)
+		if err != nil {
+			log.Fatal(err)
+		}
+		s, n, err := treeMap.fewestTrees(limit.right, limit.down)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Fewest trees: %d going %v", n, s)
+		return
 	}
-	p2 *= treeMap.treesEncountered
 
-	treeMap.reset()
-	for more := true; more; more = treeMap.traverse(5, 1) {
+	p1, err := treeMap.trees(slope{3, 1})
+	if err != nil {
+		log.Fatal(err)
 	}
-	p2 *= treeMap.treesEncountered
+	log.Printf("Part One: %d", p1)
 
-	treeMap.reset()
-	for more := true; more; more = treeMap.traverse(7, 1) {
+	// Part 2, get all of these plans and multiply their results
+	counts, err := treeMap.treesOnSlopes(part2Slopes)
+	if err != nil {
+		log.Fatal(err)
 	}
-	p2 *= treeMap.treesEncountered
-
-	treeMap.reset()
-	for more := true; more; more = treeMap.traverse(1, 2) {
+	p2 := 1
+	for _, n := range counts {
+		p2 *= n
 	}
-	p2 *= treeMap.treesEncountered
 
 	log.Printf("Part Two: %d", p2)
 }
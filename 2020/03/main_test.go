@@ -123,113 +123,79 @@ func TestAddLayer(t *testing.T) {
 	}
 }
 
-func TestTraversal(t *testing.T) {
+func TestPath(t *testing.T) {
 	type test struct {
-		name    string
-		treeMap treeMap
-		input   struct {
-			x int
-			y int
-		}
-		more   bool
-		curPos struct {
-			x int
-			y int
-		}
-		trees int
+		name      string
+		treeMap   treeMap
+		slope     slope
+		err       error
+		positions []position
+		trees     int
 	}
 	tests := []test{
 		{
-			"Simple Case",
+			"Never going down is an error",
 			treeMap{layers: []treeLayer{treeLayer{[]bool{false}}, treeLayer{[]bool{false}}}},
-			struct {
-				x int
-				y int
-			}{0, 0},
-			true,
-			struct {
-				x int
-				y int
-			}{0, 0},
+			slope{0, 0},
+			ErrTraversal,
+			nil,
 			0,
 		},
 		{
 			"Down 1 Right 1 Case No Trees",
 			treeMap{layers: []treeLayer{treeLayer{[]bool{false, false}}, treeLayer{[]bool{false, false}}}},
-			struct {
-				x int
-				y int
-			}{1, 1},
-			false,
-			struct {
-				x int
-				y int
-			}{1, 1},
+			slope{1, 1},
+			nil,
+			[]position{{1, 1}},
 			0,
 		},
 		{
 			"Down 1 Right 2 Case Some Trees",
 			treeMap{layers: []treeLayer{treeLayer{[]bool{true, false, true}}, treeLayer{[]bool{false, false, true}}}},
-			struct {
-				x int
-				y int
-			}{2, 1},
-			false,
-			struct {
-				x int
-				y int
-			}{2, 1},
+			slope{2, 1},
+			nil,
+			[]position{{2, 1}},
 			1,
 		},
 		{
 			"Moving Right should be a repeating pattern",
-			treeMap{layers: []treeLayer{treeLayer{[]bool{true, false}}}},
-			struct {
-				x int
-				y int
-			}{10, 0},
-			false,
-			struct {
-				x int
-				y int
-			}{10, 0},
+			treeMap{layers: []treeLayer{treeLayer{[]bool{false, false}}, treeLayer{[]bool{true, false}}}},
+			slope{10, 1},
+			nil,
+			[]position{{10, 1}},
+			1,
+		},
+		{
+			"Going Down past the bottom stops",
+			treeMap{layers: []treeLayer{treeLayer{[]bool{false}}, treeLayer{[]bool{true}}, treeLayer{[]bool{true}}}},
+			slope{0, 2},
+			nil,
+			[]position{{0, 2}},
 			1,
 		},
 	}
 
 	for _, tst := range tests {
 		t.Run(tst.name, func(t *testing.T) {
-			more := tst.treeMap.traverse(tst.input.x, tst.input.y)
-			if more != tst.more {
-				t.Errorf("Expected more %v got %v", tst.more, more)
+			positions, err := tst.treeMap.path(tst.slope)
+			if !errors.Is(err, tst.err) {
+				t.Fatalf("Expected %v but got %v", tst.err, err)
 			}
-			if tst.treeMap.curPos != tst.curPos {
-				t.Errorf("Expected new curPos to be: %+v got %+v", tst.treeMap.curPos, tst.curPos)
+			if len(positions) != len(tst.positions) {
+				t.Fatalf("Expected positions %+v got %+v", tst.positions, positions)
 			}
-			if tst.trees != tst.treeMap.treesEncountered {
-				t.Errorf("Expected trees %d got %d", tst.trees, tst.treeMap.treesEncountered)
+			for i := range positions {
+				if positions[i] != tst.positions[i] {
+					t.Fatalf("Expected positions %+v got %+v", tst.positions, positions)
+				}
 			}
-		})
-	}
-}
-
-func TestReset(t *testing.T) {
-	m := treeMap{curPos: struct {
-		x int
-		y int
-	}{5, 5},
-		treesEncountered: 5,
-	}
-	m.reset()
 
-	if m.curPos != struct {
-		x int
-		y int
-	}{0, 0} {
-		t.Errorf("Expected curPos to be reset, was actually %+v", m.curPos)
-	}
-
-	if m.treesEncountered != 0 {
-		t.Errorf("Expected treesEncoutnered to be reset, was actually %+v", m.treesEncountered)
+			if err != nil {
+				return
+			}
+			if trees, err := tst.treeMap.trees(tst.slope); err != nil || trees != tst.trees {
+				t.Errorf("Expected trees %d got %d w/ err %v", tst.trees, trees, err)
+			}
+		})
 	}
 }
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// a slope is how far right and down the toboggan goes each step
+type slope struct {
+	right, down int
+}
+
+func (s slope) String() string {
+	return fmt.Sprintf("right %d, down %d", s.right, s.down)
+}
+
+// the slopes part two asks about
+var part2Slopes = []slope{{1, 1}, {3, 1}, {5, 1}, {7, 1}, {1, 2}}
+
+// position is somewhere on the map, with x not yet wrapped around the layer
+type position struct {
+	x, y int
+}
+
+// path is every position the toboggan stops at going down s, not counting the start
+// it only reads the map, so any number of paths can be walked at the same time
+func (t *treeMap) path(s slope) ([]position, error) {
+	if s.down < 1 || s.right < 0 {
+		return nil, fmt.Errorf("%w: %v never reaches the bottom", ErrTraversal, s)
+	}
+
+	var positions []position
+	for p := (position{s.right, s.down}); p.y < len(t.layers); p.x, p.y = p.x+s.right, p.y+s.down {
+		positions = append(positions, p)
+	}
+	return positions, nil
+}
+
+// isTree is whether there's a tree at p, remembering the map repeats to the right
+func (t *treeMap) isTree(p position) bool {
+	trees := t.layers[p.y].trees
+	return len(trees) > 0 && trees[p.x%len(trees)]
+}
+
+// trees counts the trees hit going down s
+func (t *treeMap) trees(s slope) (int, error) {
+	positions, err := t.path(s)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	for _, p := range positions {
+		if t.isTree(p) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// treesOnSlopes counts the trees hit going down every slope, all at once
+// the counts are in the same order as slopes
+func (t *treeMap) treesOnSlopes(slopes []slope) ([]int, error) {
+	counts := make([]int, len(slopes))
+
+	group := new(errgroup.Group)
+	for i, s := range slopes {
+		i, s := i, s
+		group.Go(func() error {
+			n, err := t.trees(s)
+			counts[i] = n
+			return err
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// fewestTrees tries every slope going up to maxRight right and maxDown down,
+// and finds the one that hits the fewest trees
+// ties go to the slope that goes down the least, and then right the least
+func (t *treeMap) fewestTrees(maxRight, maxDown int) (slope, int, error) {
+	var slopes []slope
+	for down := 1; down <= maxDown; down++ {
+		for right := 0; right <= maxRight; right++ {
+			slopes = append(slopes, slope{right, down})
+		}
+	}
+	if len(slopes) == 0 {
+		return slope{}, 0, fmt.Errorf("%w: no slopes to try going %d right and %d down", ErrTraversal, maxRight, maxDown)
+	}
+
+	counts, err := t.treesOnSlopes(slopes)
+	if err != nil {
+		return slope{}, 0, err
+	}
+
+	best := 0
+	for i, n := range counts {
+		if n < counts[best] {
+			best = i
+		}
+	}
+	return slopes[best], counts[best], nil
+}
+
+// renderPath draws the map the way the puzzle does,
+// repeated to the right as many times as it takes to fit the whole path,
+// with every stop marked O if it's open, or X if it's a tree
+func (t *treeMap) renderPath(w io.Writer, s slope) error {
+	positions, err := t.path(s)
+	if err != nil {
+		return err
+	}
+
+	visited := make(map[position]bool, len(positions))
+	widest := 1
+	for _, p := range positions {
+		visited[p] = true
+		if p.x+1 > widest {
+			widest = p.x + 1
+		}
+	}
+
+	for y, layer := range t.layers {
+		if len(layer.trees) == 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// always whole copies of the layer
+		repeats := (widest + len(layer.trees) - 1) / len(layer.trees)
+		row := make([]byte, repeats*len(layer.trees))
+		for x := range row {
+			tree := layer.trees[x%len(layer.trees)]
+			switch {
+			case visited[position{x, y}] && tree:
+				row[x] = 'X'
+			case visited[position{x, y}]:
+				row[x] = 'O'
+			case tree:
+				row[x] = '#'
+			default:
+				row[x] = '.'
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
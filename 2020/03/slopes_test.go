@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const sampleMap = `..##.......
+#...#...#..
+.#....#..#.
+..#.#...#.#
+.#...##..#.
+..#.##.....
+.#.#.#....#
+.#........#
+#.##...#...
+#...##....#
+.#..#...#.#`
+
+func sampleTreeMap(t *testing.T) *treeMap {
+	m := newTreeMap()
+	for _, line := range strings.Split(sampleMap, "\n") {
+		layer, err := newTreeLayer(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.addLayer(*layer)
+	}
+	return m
+}
+
+func TestTreesOnSlopes(t *testing.T) {
+	m := sampleTreeMap(t)
+
+	counts, err := m.treesOnSlopes(part2Slopes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []int{2, 7, 3, 4, 2} {
+		if counts[i] != expected {
+			t.Errorf("Expected %d trees going %v, got %d", expected, part2Slopes[i], counts[i])
+		}
+	}
+
+	if _, err := m.treesOnSlopes([]slope{{3, 1}, {1, 0}}); !errors.Is(err, ErrTraversal) {
+		t.Errorf("Expected %v for a slope that never goes down, got %v", ErrTraversal, err)
+	}
+}
+
+func TestFewestTrees(t *testing.T) {
+	m := sampleTreeMap(t)
+
+	s, n, err := m.fewestTrees(7, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// nothing in the search space can do better
+	for down := 1; down <= 2; down++ {
+		for right := 0; right <= 7; right++ {
+			if other, _ := m.trees(slope{right, down}); other < n {
+				t.Errorf("Expected %v to hit the fewest trees (%d), but %v only hits %d", s, n, slope{right, down}, other)
+			}
+		}
+	}
+
+	if _, _, err := m.fewestTrees(7, 0); !errors.Is(err, ErrTraversal) {
+		t.Errorf("Expected %v for an empty search space, got %v", ErrTraversal, err)
+	}
+}
+
+func TestRenderPath(t *testing.T) {
+	m := sampleTreeMap(t)
+
+	var b bytes.Buffer
+	if err := m.renderPath(&b, slope{3, 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	rows := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(rows) != len(m.layers) {
+		t.Fatalf("Expected %d rows, got %d", len(m.layers), len(rows))
+	}
+
+	// the path ends at x=30, so the 11 wide map has to be repeated 3 times
+	for _, expected := range []struct {
+		y   int
+		row string
+	}{
+		{0, "..##.........##.........##......."},
+		{1, "#..O#...#..#...#...#..#...#...#.."},
+		{2, ".#....X..#..#....#..#..#....#..#."},
+		{10, ".#..#...#.#.#..#...#.#.#..#...X.#"},
+	} {
+		if rows[expected.y] != expected.row {
+			t.Errorf("Expected row %d to be %q, got %q", expected.y, expected.row, rows[expected.y])
+		}
+	}
+
+	if x := strings.Count(b.String(), "X"); x != 7 {
+		t.Errorf("Expected 7 trees marked, got %d", x)
+	}
+	if o := strings.Count(b.String(), "O"); o != 3 {
+		t.Errorf("Expected 3 open squares marked, got %d", o)
+	}
+}
+
+func TestParseSlope(t *testing.T) {
+	if s, err := parseSlope("3,1"); err != nil || s != (slope{3, 1}) {
+		t.Errorf("Expected right 3, down 1, got %v w/ err %v", s, err)
+	}
+	if _, err := parseSlope("3"); !errors.Is(err, ErrTraversal) {
+		t.Errorf("Expected %v, got %v", ErrTraversal, err)
+	}
+}
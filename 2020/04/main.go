@@ -1,13 +1,14 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"log"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"gitlab.com/travisby/advent/input"
 )
 
 var pidRe = regexp.MustCompile("^\\d{9}$")
@@ -134,23 +135,15 @@ func main() {
 		}
 	}()
 
-	scanner := bufio.NewScanner(f)
-
-	passports := []passport{}
-	var p passport
-
-	for scanner.Scan() {
-		if scanner.Text() == "" {
-			passports = append(passports, p)
-			p = passport{}
-		}
-
-		for _, t := range strings.Split(scanner.Text(), " ") {
+	// passports are separated by blank lines, and their fields by spaces or newlines
+	passports, err := input.ParseTokens(f, input.ScanGroups, func(s string) (passport, error) {
+		var p passport
+		for _, t := range strings.Fields(s) {
 			p.addTV(tv(t))
 		}
-	}
-
-	if err := scanner.Err(); err != nil {
+		return p, nil
+	})
+	if err != nil {
 		log.Fatal(err)
 	}
 
@@ -1,9 +1,11 @@
 package main
 
 import (
-	"bufio"
 	"log"
 	"os"
+	"strings"
+
+	"gitlab.com/travisby/advent/input"
 )
 
 // a person is identified by all of the Questions they answered yes to
@@ -72,26 +74,18 @@ func main() {
 		}
 	}()
 
-	var gs []group
-	var g group
-
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		if scanner.Text() == "" {
-			gs = append(gs, g)
-			g = group{}
-			continue
+	// groups are separated by blank lines, with one person per line
+	gs, err := input.ParseTokens(f, input.ScanGroups, func(s string) (group, error) {
+		var g group
+		for _, p := range strings.Split(s, "\n") {
+			g = append(g, toPerson(p))
 		}
-		g = append(g, toPerson(scanner.Text()))
-	}
-
-	if err := scanner.Err(); err != nil {
+		return g, nil
+	})
+	if err != nil {
 		log.Fatal(err)
 	}
 
-	// handle last group on ending input!
-	gs = append(gs, g)
-
 	var count uint
 	for _, g := range gs {
 		count += g.count()
@@ -7,6 +7,8 @@ import (
 	"log"
 	"os"
 	"strconv"
+
+	"gitlab.com/travisby/advent/ksum"
 )
 
 const preambleSize = 25
@@ -27,14 +29,10 @@ func newDecoderRing(preamble []int) *greedyDecoderRing {
 }
 
 func (d *greedyDecoderRing) next(i int) error {
-	km := d.toKeyMap()
-
-	for k := range km {
-		// if there exists the key that is i - our current number
-		// (aka i-k + k = i), where (i-k) exists
-		if _, ok := km[i-k]; ok {
-			// valid!
-
+	// valid if two different numbers of the last sz add up to i
+	// ksum would happily use a number twice if it's in the window twice, so those don't count
+	for _, pair := range ksum.Solve(d.window(), 2, i) {
+		if pair[0] != pair[1] {
 			d.ring = append(d.ring, i)
 			d.zeroPos++
 			return nil
@@ -46,12 +44,9 @@ func (d *greedyDecoderRing) next(i int) error {
 
 var ErrWrongStuffing = errors.New("Someone stuffed something baaad in here")
 
-func (d *greedyDecoderRing) toKeyMap() map[int]struct{} {
-	m := map[int]struct{}{}
-	for _, k := range d.ring[len(d.ring)-d.sz:] {
-		m[k] = struct{}{}
-	}
-	return m
+// window is the last sz numbers, the only ones the next number can be made of
+func (d *greedyDecoderRing) window() []int {
+	return d.ring[len(d.ring)-d.sz:]
 }
 
 func main() {
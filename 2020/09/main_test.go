@@ -0,0 +1,18 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNextNeedsDifferentNumbers(t *testing.T) {
+	d := newDecoderRing([]int{25, 1, 25})
+
+	// 25 shows up twice, but it still can't be paired with itself
+	if err := d.next(50); !errors.Is(err, ErrInvalidNext) {
+		t.Errorf("Expected %v for 50, got %v", ErrInvalidNext, err)
+	}
+	if err := d.next(26); err != nil {
+		t.Errorf("Expected 26 to be valid, got %v", err)
+	}
+}
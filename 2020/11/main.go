@@ -1,12 +1,12 @@
 package main
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"log"
 	"os"
-	"strings"
+
+	"gitlab.com/travisby/advent/grid"
 )
 
 type seat rune
@@ -16,42 +16,18 @@ var EMPTY seat = 'L'
 var OCCUPIED seat = '#'
 var ErrInvalidSeat = errors.New("ErrInvalidSeat")
 
-type seatLayout [][]seat
-
-// String() aids in debugging
-// while also being useful as a hashing function
-// e.g. let us compare equality of layouts
-func (s seatLayout) String() string {
-	if len(s) == 0 {
-		return ""
-	}
-
-	var b strings.Builder
-	// since we know each row is the same size
-	// we can assume our size is col*row
-	// and just account for potential already capacity
-	// (which I think is unlikely, but meh)
-	b.Grow((len(s) * len(s[0])) - b.Cap())
-	for _, i := range s {
-		for _, j := range i {
-			// ignore err
-			b.WriteRune(rune(j))
-		}
-		// ignore err
-		b.WriteRune('\n')
-	}
-	// remove the extra \n
-	return b.String()[:b.Len()-1]
+type seatLayout struct {
+	*grid.Grid[seat]
+}
 
+// String() lets the grid draw us
+// which is also how we compare layouts for equality
+func (s seat) String() string {
+	return string(s)
 }
 
 func (a seatLayout) DeepCopy() (b seatLayout) {
-	b = make([][]seat, len(a))
-	for i := range a {
-		b[i] = make([]seat, len(a[i]))
-		copy(b[i], a[i])
-	}
-	return b
+	return seatLayout{a.Clone()}
 }
 
 func (a seatLayout) PerformRound() seatLayout {
@@ -60,205 +36,67 @@ func (a seatLayout) PerformRound() seatLayout {
 		If a seat is occupied (#) and four or more seats adjacent to it are also occupied, the seat becomes empty.
 		Otherwise, the seat's state does not change.
 	*/
-
-	b := a.DeepCopy()
-
-	for i := range b {
-		for j := range a[i] {
-			if a[i][j] == EMPTY {
-				if a.numAdjacentOccupied(i, j) == 0 {
-					b[i][j] = OCCUPIED
-				}
-			} else if a[i][j] == OCCUPIED {
-				if a.numAdjacentOccupied(i, j) >= 4 {
-					b[i][j] = EMPTY
-				}
-			} else if a[i][j] == FLOOR {
-				// ignore
-			}
-		}
-	}
-
-	return b
+	return a.performRound(a.numAdjacentOccupied, 4)
 }
 
 func (a seatLayout) PerformRound2() seatLayout {
 	/*
-		If a seat is empty (L) and there are no occupied seats adjacent to it, the seat becomes occupied.
-		If a seat is occupied (#) and four or more seats adjacent to it are also occupied, the seat becomes empty.
-		Otherwise, the seat's state does not change.
+		Same as PerformRound, except that we look at the first seat in sight in each direction
+		and it takes five or more occupied seats for an occupied seat to become empty
 	*/
+	return a.performRound(a.numInSightOccupied, 5)
+}
 
+func (a seatLayout) performRound(numOccupied func(grid.Point) int, tolerance int) seatLayout {
 	b := a.DeepCopy()
 
-	for i := range b {
-		for j := range a[i] {
-			if a[i][j] == EMPTY {
-				if a.numInSightOccupied(i, j) == 0 {
-					b[i][j] = OCCUPIED
-				}
-			} else if a[i][j] == OCCUPIED {
-				if a.numInSightOccupied(i, j) >= 5 {
-					b[i][j] = EMPTY
-				}
-			} else if a[i][j] == FLOOR {
-				// ignore
+	for _, p := range a.Points() {
+		switch a.Get(p) {
+		case EMPTY:
+			if numOccupied(p) == 0 {
+				b.Set(p, OCCUPIED)
 			}
+		case OCCUPIED:
+			if numOccupied(p) >= tolerance {
+				b.Set(p, EMPTY)
+			}
+		case FLOOR:
+			// ignore
 		}
 	}
 
 	return b
 }
 
-func (a seatLayout) numAdjacentOccupied(i, j int) int {
-	adjacents := make([]seat, 0, 8)
-
-	// top row
-	if i-1 >= 0 {
-		if j-1 >= 0 {
-			adjacents = append(adjacents, a[i-1][j-1])
-		}
-		adjacents = append(adjacents, a[i-1][j])
-		if j+1 < len(a[i-1]) {
-			adjacents = append(adjacents, a[i-1][j+1])
-		}
-	}
-	// my row
-	if j-1 >= 0 {
-		adjacents = append(adjacents, a[i][j-1])
-	}
-	if j+1 < len(a[i]) {
-		adjacents = append(adjacents, a[i][j+1])
-	}
-	// bottom row
-	if i+1 < len(a) {
-		if j-1 >= 0 {
-			adjacents = append(adjacents, a[i+1][j-1])
-		}
-		adjacents = append(adjacents, a[i+1][j])
-		if j+1 < len(a[i+1]) {
-			adjacents = append(adjacents, a[i+1][j+1])
-		}
-	}
-
+func (a seatLayout) numAdjacentOccupied(p grid.Point) int {
 	countOccupied := 0
-	for _, v := range adjacents {
-		if v == OCCUPIED {
+	for _, n := range a.Neighbors8(p) {
+		if a.Get(n) == OCCUPIED {
 			countOccupied++
 		}
 	}
 	return countOccupied
 }
 
-func (a seatLayout) numInSightOccupied(y, x int) int {
-	count := 0
-
-	// north
-	for i := y - 1; i >= 0; i-- {
-		if a[i][x] == OCCUPIED {
-			count++
-			break
-		} else if a[i][x] == EMPTY {
-			break
-		}
-	}
-
-	// south
-	for i := y + 1; i < len(a); i++ {
-		if a[i][x] == OCCUPIED {
-			count++
-			break
-		} else if a[i][x] == EMPTY {
-			break
-		}
-	}
-
-	// east
-	for i := x + 1; i < len(a[y]); i++ {
-		if a[y][i] == OCCUPIED {
-			count++
-			break
-		} else if a[y][i] == EMPTY {
-			break
-		}
-	}
-
-	// west
-	for i := x - 1; i >= 0; i-- {
-		if a[y][i] == OCCUPIED {
-			count++
-			break
-		} else if a[y][i] == EMPTY {
-			break
-		}
+func (a seatLayout) numInSightOccupied(p grid.Point) int {
+	isSeat := func(s seat) bool {
+		return s != FLOOR
 	}
 
-	var i, j int
-	i = y + 1
-	j = x + 1
-	for i >= 0 && j >= 0 && i < len(a) && j < len(a[i]) {
-		if a[i][j] == OCCUPIED {
-			count++
-			break
-		} else if a[i][j] == EMPTY {
-			break
-		}
-		i++
-		j++
-	}
-
-	i = y + 1
-	j = x - 1
-	for i >= 0 && j >= 0 && i < len(a) && j < len(a[i]) {
-		if a[i][j] == OCCUPIED {
-			count++
-			break
-		} else if a[i][j] == EMPTY {
-			break
-		}
-		i++
-		j--
-	}
-
-	i = y - 1
-	j = x + 1
-	for i >= 0 && j >= 0 && i < len(a) && j < len(a[i]) {
-		if a[i][j] == OCCUPIED {
-			count++
-			break
-		} else if a[i][j] == EMPTY {
-			break
-		}
-		i--
-		j++
-	}
-
-	i = y - 1
-	j = x - 1
-	for i >= 0 && j >= 0 && i < len(a) && j < len(a[i]) {
-		if a[i][j] == OCCUPIED {
+	count := 0
+	for _, d := range grid.All {
+		if seen, ok := a.Cast(p, d, isSeat); ok && a.Get(seen) == OCCUPIED {
 			count++
-			break
-		} else if a[i][j] == EMPTY {
-			break
 		}
-		i--
-		j--
 	}
 
 	return count
 }
 
 func (a seatLayout) numOccupied() int {
-	numOccupied := 0
-	for _, i := range a {
-		for _, j := range i {
-			if j == OCCUPIED {
-				numOccupied++
-			}
-		}
-	}
-	return numOccupied
+	return a.Count(func(s seat) bool {
+		return s == OCCUPIED
+	})
 }
 
 func newSeat(c rune) (*seat, error) {
@@ -289,25 +127,17 @@ func main() {
 		}
 	}()
 
-	var layout seatLayout
-
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		seats := make([]seat, 0, len(scanner.Text()))
-
-		for _, c := range scanner.Text() {
-			s, err := newSeat(c)
-			if err != nil {
-				log.Fatal(err)
-			}
-			seats = append(seats, *s)
+	g, err := grid.Parse(f, func(c rune) (seat, error) {
+		s, err := newSeat(c)
+		if err != nil {
+			return 0, err
 		}
-
-		layout = append(layout, seats)
-	}
-	if err := scanner.Err(); err != nil {
+		return *s, nil
+	})
+	if err != nil {
 		log.Fatal(err)
 	}
+	layout := seatLayout{g}
 
 	layoutCopy := layout.DeepCopy()
 
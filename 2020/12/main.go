@@ -4,43 +4,28 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
-	"image"
 	"log"
-	"math"
 	"os"
 	"strconv"
-)
-
-type direction int
 
-const (
-	North direction = 0
-	South           = 180
-	East            = 90
-	West            = 270
+	"gitlab.com/travisby/advent/geom"
 )
 
 type plane struct {
-	d *direction
-	p *image.Point
+	d geom.Direction
+	p geom.Point
 	// waypoint is RELATIVE to the ship
 	// not in absolute space
-	w *image.Point
+	w geom.Point
 }
 
 func (p plane) manhattanDistance() int {
-	return int(math.Abs(float64(p.p.X)) + math.Abs(float64(p.p.Y)))
+	return p.p.Manhattan(geom.Origin)
 }
 
 func newPlane() *plane {
-	p := plane{}
-
-	var temp direction = East
-	p.d = &temp
-	p.p = &image.Point{}
-	p.w = &image.Point{10, 1}
-
-	return &p
+	// the waypoint starts 10 units east and 1 unit north
+	return &plane{d: geom.East, w: geom.E.Mul(10).Add(geom.N)}
 }
 
 type Instruction interface {
@@ -80,71 +65,54 @@ func toInstruction(s string) (Instruction, error) {
 type north int
 
 func (n north) Apply(p *plane) {
-	*p.p = p.p.Add(image.Point{0, int(n)})
+	p.p = p.p.Add(geom.N.Mul(int(n)))
 }
 func (n north) Apply2(p *plane) {
-	*p.w = p.w.Add(image.Point{0, int(n)})
+	p.w = p.w.Add(geom.N.Mul(int(n)))
 }
 
 type south int
 
 func (s south) Apply(p *plane) {
-	*p.p = p.p.Add(image.Point{0, -int(s)})
+	p.p = p.p.Add(geom.S.Mul(int(s)))
 }
 func (s south) Apply2(p *plane) {
-	*p.w = p.w.Add(image.Point{0, -int(s)})
+	p.w = p.w.Add(geom.S.Mul(int(s)))
 }
 
 type east int
 
 func (e east) Apply(p *plane) {
-	*p.p = p.p.Add(image.Point{int(e), 0})
+	p.p = p.p.Add(geom.E.Mul(int(e)))
 }
 func (e east) Apply2(p *plane) {
-	*p.w = p.w.Add(image.Point{int(e), 0})
+	p.w = p.w.Add(geom.E.Mul(int(e)))
 }
 
 type west int
 
 func (w west) Apply(p *plane) {
-	*p.p = p.p.Add(image.Point{-int(w), 0})
+	p.p = p.p.Add(geom.W.Mul(int(w)))
 }
 func (w west) Apply2(p *plane) {
-	*p.w = p.w.Add(image.Point{-int(w), 0})
+	p.w = p.w.Add(geom.W.Mul(int(w)))
 }
 
 type left int
 
 func (l left) Apply(p *plane) {
-	// I think we're assuming only 90s?
-	switch (int(*p.d) - int(l)) % 360 {
-	case 0:
-		*p.d = North
-	case 90, -270:
-		*p.d = East
-	case 180, -180:
-		*p.d = South
-	case 270, -90:
-		*p.d = West
-	default:
-		log.Fatal("Not one of the four angles we expected")
+	d, err := p.d.Turn(-int(l))
+	if err != nil {
+		log.Fatal(err)
 	}
+	p.d = d
 }
 func (l left) Apply2(p *plane) {
-	turnsToMove := int(l) / 90
-
-	// (10,4) -> (-4, 10)
-	for turnsToMove > 0 {
-		turnsToMove--
-		*p.w = image.Point{-p.w.Y, p.w.X}
-
-	}
-
-	// (10,4) -> (4, -10)
-	for turnsToMove < 0 {
-		turnsToMove++
-		*p.w = image.Point{p.w.Y, -p.w.X}
+	w, err := p.w.Rotate(-int(l))
+	if err != nil {
+		log.Fatal(err)
 	}
+	p.w = w
 }
 
 type right int
@@ -159,19 +127,10 @@ func (r right) Apply2(p *plane) {
 type forward int
 
 func (f forward) Apply(p *plane) {
-	switch *p.d {
-	case North:
-		north(f).Apply(p)
-	case South:
-		south(f).Apply(p)
-	case East:
-		east(f).Apply(p)
-	case West:
-		west(f).Apply(p)
-	}
+	p.p = p.p.Add(p.d.Vector().Mul(int(f)))
 }
 func (f forward) Apply2(p *plane) {
-	*p.p = p.p.Add(p.w.Mul(int(f)))
+	p.p = p.p.Add(p.w.Mul(int(f)))
 }
 
 func main() {
@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"strconv"
+
+	"gitlab.com/travisby/advent/input"
 )
 
 type school [9]uint
@@ -34,24 +36,6 @@ func (s *school) advance() {
 
 }
 
-func ScanWordsCommaSplit(data []byte, atEOF bool) (advance int, token []byte, err error) {
-	if atEOF && len(data) == 0 {
-		data = nil
-	}
-	if atEOF {
-		return len(data), data, nil
-	}
-
-	var i int
-	for i = range string(data) {
-		if data[i] == ',' {
-			break
-		}
-	}
-
-	return i + 1, data[:i], nil
-}
-
 func main() {
 	var f *os.File
 	if len(os.Args) == 2 {
@@ -71,7 +55,7 @@ func main() {
 	}()
 
 	scanner := bufio.NewScanner(f)
-	scanner.Split(ScanWordsCommaSplit)
+	scanner.Split(input.ScanCommas)
 
 	// fishAtDay stores how many fish are at $day in their lifecycle
 	// this number is from 0-8, 8 being reserved for new feesh
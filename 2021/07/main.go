@@ -6,25 +6,9 @@ import (
 	"math"
 	"os"
 	"strconv"
-)
 
-func ScanWordsCommaSplit(data []byte, atEOF bool) (advance int, token []byte, err error) {
-	if atEOF && len(data) == 0 {
-		data = nil
-	}
-	if atEOF {
-		return len(data), data, nil
-	}
-
-	var i int
-	for i = range string(data) {
-		if data[i] == ',' {
-			break
-		}
-	}
-
-	return i + 1, data[:i], nil
-}
+	"gitlab.com/travisby/advent/input"
+)
 
 func main() {
 	var f *os.File
@@ -45,7 +29,7 @@ func main() {
 	}()
 
 	scanner := bufio.NewScanner(f)
-	scanner.Split(ScanWordsCommaSplit)
+	scanner.Split(input.ScanCommas)
 
 	positions := map[int64]int64{}
 	var sum int64
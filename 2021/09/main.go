@@ -1,85 +1,29 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"log"
 	"os"
 	"sort"
 	"strconv"
-)
-
-type point struct {
-	x, y int
-}
-
-func deduplicate(ps []point) []point {
-	tempM := map[point]bool{}
-	for _, p := range ps {
-		tempM[p] = true
-	}
-
-	temp := make([]point, 0, len(tempM))
-	for p := range tempM {
-		temp = append(temp, p)
-	}
-	return temp
-}
-
-func (p point) left() point {
-	return point{p.x - 1, p.y}
-}
 
-func (p point) right() point {
-	return point{p.x + 1, p.y}
-}
-
-func (p point) up() point {
-	return point{p.x, p.y + 1}
-}
-
-func (p point) down() point {
-	return point{p.x, p.y - 1}
-}
-
-type heightmap [][]uint8
+	"gitlab.com/travisby/advent/graph"
+	"gitlab.com/travisby/advent/grid"
+)
 
-func (h heightmap) heightAt(p point) uint8 {
-	return h[p.x][p.y]
+type heightmap struct {
+	*grid.Grid[uint8]
 }
 
-func (h heightmap) rows() int {
-	return len(h)
+func (h heightmap) heightAt(p grid.Point) uint8 {
+	return h.Get(p)
 }
 
-// xxx: assumes uniform rows
-func (h heightmap) columns() int {
-	if len(h) == 0 {
-		return 0
-	}
-	return len(h[0])
+func (h heightmap) adjacentPoints(p grid.Point) []grid.Point {
+	return h.Neighbors4(p)
 }
 
-func (h heightmap) adjacentPoints(p point) []point {
-	var results []point
-
-	if p.x != 0 && h.rows() > 0 {
-		results = append(results, p.left())
-	}
-	if p.x < h.rows()-1 && h.rows() > 0 {
-		results = append(results, p.right())
-	}
-	if p.y != 0 && h.columns() > 0 {
-		results = append(results, p.down())
-	}
-	if p.y < h.columns()-1 && h.columns() > 0 {
-		results = append(results, p.up())
-	}
-
-	return results
-}
-
-func (h heightmap) adjacentHeights(p point) []uint8 {
+func (h heightmap) adjacentHeights(p grid.Point) []uint8 {
 	results := []uint8{}
 	for _, adjacent := range h.adjacentPoints(p) {
 		results = append(results, h.heightAt(adjacent))
@@ -88,11 +32,11 @@ func (h heightmap) adjacentHeights(p point) []uint8 {
 	return results
 }
 
-func (h heightmap) isLowPoint(p point) bool {
+func (h heightmap) isLowPoint(p grid.Point) bool {
 	return h.riskLevel(p) != 0
 }
 
-func (h heightmap) riskLevel(p point) uint8 {
+func (h heightmap) riskLevel(p grid.Point) uint8 {
 	ourHeight := h.heightAt(p)
 	for _, adjacentHeight := range h.adjacentHeights(p) {
 		if ourHeight >= adjacentHeight {
@@ -102,21 +46,23 @@ func (h heightmap) riskLevel(p point) uint8 {
 	return ourHeight + 1
 }
 
-func (h heightmap) basin(p point) []point {
-	var results []point
+func (h heightmap) basin(p grid.Point) []grid.Point {
 	if h.heightAt(p) == 9 {
-		return results
+		return nil
 	}
-	results = append(results, p)
 
-	for _, adjacent := range h.adjacentPoints(p) {
-		// strictly less-than, or else we'd have a loop
-		if h.heightAt(p) < h.heightAt(adjacent) {
-			results = append(results, h.basin(adjacent)...)
+	// flood outwards from the low point, only ever climbing
+	climb := func(p grid.Point) []grid.Point {
+		var results []grid.Point
+		for _, adjacent := range h.adjacentPoints(p) {
+			if h.heightAt(adjacent) != 9 && h.heightAt(p) < h.heightAt(adjacent) {
+				results = append(results, adjacent)
+			}
 		}
+		return results
 	}
 
-	return deduplicate(results)
+	return graph.BFS(climb, p).Reached()
 }
 
 func main() {
@@ -137,35 +83,22 @@ func main() {
 		}
 	}()
 
-	scanner := bufio.NewScanner(f)
-
-	var hm heightmap
-	for scanner.Scan() {
-		row := make([]uint8, len(scanner.Text()))
-		for i, c := range scanner.Text() {
-			num, err := strconv.ParseUint(fmt.Sprintf("%c", c), 10, 8)
-			if err != nil {
-				log.Fatal(err)
-			}
-			row[i] = uint8(num)
-		}
-
-		hm = append(hm, row)
-	}
-	if err := scanner.Err(); err != nil {
+	g, err := grid.Parse(f, func(c rune) (uint8, error) {
+		num, err := strconv.ParseUint(fmt.Sprintf("%c", c), 10, 8)
+		return uint8(num), err
+	})
+	if err != nil {
 		log.Fatal(err)
 	}
+	hm := heightmap{g}
 
 	var sumRiskLevels int
 	var basinSizes []int
-	for x := 0; x < hm.rows(); x++ {
-		for y := 0; y < hm.columns(); y++ {
-			p := point{x, y}
-			sumRiskLevels += int(hm.riskLevel(p))
+	for _, p := range hm.Points() {
+		sumRiskLevels += int(hm.riskLevel(p))
 
-			if hm.isLowPoint(p) {
-				basinSizes = append(basinSizes, len(hm.basin(p)))
-			}
+		if hm.isLowPoint(p) {
+			basinSizes = append(basinSizes, len(hm.basin(p)))
 		}
 	}
 
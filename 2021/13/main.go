@@ -1,12 +1,15 @@
 package main
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"log"
 	"os"
 	// "sort"
+
+	"gitlab.com/travisby/advent/geom"
+	"gitlab.com/travisby/advent/input"
+	"gitlab.com/travisby/advent/ocr"
 )
 
 var ErrInvalidInput = errors.New("Invalid Input")
@@ -95,6 +98,15 @@ func (t transparentPaper) String() string {
 	return string(str)
 }
 
+// Read is the letters the dots spell out
+func (t transparentPaper) Read() (string, error) {
+	points := make([]geom.Point, 0, len(t))
+	for p := range t {
+		points = append(points, geom.Pt(p.x, p.y))
+	}
+	return ocr.Points(points)
+}
+
 type FoldInstruction struct {
 	alongXAxis bool
 	i          int
@@ -112,7 +124,7 @@ func NewFoldInstruction(s string) (*FoldInstruction, error) {
 	f.alongXAxis = axis == 'x'
 
 	if axis != 'x' && axis != 'y' {
-		return nil, fmt.Errorf("%w : invalid axis, got %c", ErrInvalidInputFold, s, axis)
+		return nil, fmt.Errorf("%w : invalid axis in %q, got %c", ErrInvalidInputFold, s, axis)
 	}
 
 	return &f, nil
@@ -136,42 +148,46 @@ func main() {
 		}
 	}()
 
+	// text comes in two sections
+	// first is a series of `<x>,<y>` coordinates
+	// followed by a newline
+	// then followed by the folding instructions
+	sections, err := input.Sections(f, 2)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	points, err := input.ParseSection(sections[0], NewPoint)
+	if err != nil {
+		log.Fatal(err)
+	}
+	folds, err := input.ParseSection(sections[1], NewFoldInstruction)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	paper := make(transparentPaper)
+	for _, p := range points {
+		paper[*p] = struct{}{}
+	}
+
 	var paperAfterFirstFold transparentPaper
-	consumingPoints := true
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		// text comes into the scanner in two sections
-		// first is a series of `<x>,<y>` coordinates
-		// followed by a newline
-		// then followed by the folding instructions
-		if scanner.Text() == "" {
-			consumingPoints = false
-		} else if consumingPoints {
-			// consuming points
-			p, err := NewPoint(scanner.Text())
-			if err != nil {
-				log.Fatal(err)
-			}
-			paper[*p] = struct{}{}
-		} else {
-			// consuming fold instructions
-			f, err := NewFoldInstruction(scanner.Text())
-			if err != nil {
-				log.Fatal(err)
-			}
-			paper = paper.Fold(*f)
-			// part 1 stops after the first fold
-			if paperAfterFirstFold == nil {
-				// we need a copy or else it'll get modified in subsequent runs :o
-				paperAfterFirstFold = paper.Copy()
-			}
+	for _, fold := range folds {
+		paper = paper.Fold(*fold)
+		// part 1 stops after the first fold
+		if paperAfterFirstFold == nil {
+			// we need a copy or else it'll get modified in subsequent runs :o
+			paperAfterFirstFold = paper.Copy()
 		}
 	}
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
-	}
 
 	log.Printf("Part 1: %d", paperAfterFirstFold.NumberDotsVisible())
-	log.Printf("Part 2: \n%s", paper)
+	// if the letters can't be read, the paper is still there to read by eye
+	message, err := paper.Read()
+	if err != nil {
+		log.Printf("Could not read the paper: %v", err)
+		log.Printf("Part 2: \n%s", paper)
+	} else {
+		log.Printf("Part 2: %s", message)
+	}
 }
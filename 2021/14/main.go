@@ -1,10 +1,11 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"log"
 	"os"
+
+	"gitlab.com/travisby/advent/input"
 )
 
 type element byte
@@ -128,34 +129,24 @@ func main() {
 		}
 	}()
 
-	scanner := bufio.NewScanner(f)
-
-	if !scanner.Scan() {
-		log.Fatal("Expected polymer template")
-	} else if err := scanner.Err(); err != nil {
-		log.Fatal(scanner.Err())
+	// the polymer template, then a blank line, then the pair insertion rules
+	sections, err := input.Sections(f, 2)
+	if err != nil {
+		log.Fatal(err)
+	} else if len(sections[0].Lines) != 1 {
+		log.Fatalf("Expected a single line polymer template, got %q", sections[0].Lines)
 	}
 
-	polymerTemplate := scanner.Text()
-	polymer := NewPolymer(polymerTemplate)
+	polymer := NewPolymer(sections[0].Lines[0])
 
-	if !scanner.Scan() {
-		log.Fatal("Expected more data")
-	} else if err := scanner.Err(); err != nil {
-		log.Fatal(scanner.Err())
-	} else if txt := scanner.Text(); txt != "" {
-		log.Fatalf("Expected a newline before pair insertion rules, got %q", txt)
-	}
-
-	var rules []rule
-	for scanner.Scan() {
-		r, err := NewRule(scanner.Text())
+	rules, err := input.ParseSection(sections[1], func(s string) (rule, error) {
+		r, err := NewRule(s)
 		if err != nil {
-			log.Fatal(err)
+			return rule{}, err
 		}
-		rules = append(rules, *r)
-	}
-	if err := scanner.Err(); err != nil {
+		return *r, nil
+	})
+	if err != nil {
 		log.Fatal(err)
 	}
 
@@ -2,10 +2,11 @@ package main
 
 import (
 	"bufio"
-	"container/heap"
 	"log"
 	"os"
 	"strconv"
+
+	"gitlab.com/travisby/advent/graph"
 )
 
 type point struct {
@@ -116,43 +117,18 @@ func (c denseChitonDensityMap) Columns() int {
 }
 
 func lowestTotalRisk(c chitonDensityMap, source point) int {
-	// https://en.wikipedia.org/wiki/Dijkstra%27s_algorithm#Using_a_priority_queue
-	dist := make(map[point]int)
-
-	dist[source] = 0
-
-	var Q PriorityQueue[point]
-	heap.Init(&Q)
+	risks := func(p point) []graph.Edge[point] {
+		adjacent := c.Adjacent(p)
 
-	for x := 0; x < c.Rows(); x++ {
-		for y := 0; y < c.Columns(); y++ {
-			v := point{x, y}
-			if v != source {
-				dist[v] = 1<<32 - 1
-			}
-			heap.Push(&Q, &Item[point]{value: point{x, y}, priority: dist[v]})
-
-		}
-	}
-
-	for Q.Len() > 0 {
-		// XXX panic'able
-		u := heap.Pop(&Q).(*Item[point])
-		for _, v := range c.Adjacent(u.value) {
-			if !Q.contains(v) {
-				continue
-			}
-
-			alt := dist[u.value] + c.Get(v)
-			if alt < dist[v] {
-				dist[v] = alt
-				// XXX panic'able
-				Q.updatePriority(v, alt)
-			}
+		edges := make([]graph.Edge[point], 0, len(adjacent))
+		for _, a := range adjacent {
+			edges = append(edges, graph.Edge[point]{To: a, Cost: c.Get(a)})
 		}
+		return edges
 	}
 
-	return dist[point{c.Rows() - 1, c.Columns() - 1}]
+	dist, _ := graph.Dijkstra(risks, source).Distance(point{c.Rows() - 1, c.Columns() - 1})
+	return dist
 }
 
 func main() {
@@ -0,0 +1,105 @@
+package geom
+
+// Box is an axis-aligned rectangle
+// unlike image.Rectangle, Max is inclusive, because that's how AoC describes its areas
+// (e.g. "target area: x=20..30, y=-10..-5")
+type Box struct {
+	Min, Max Point
+}
+
+// NewBox creates the box with corners a and b, in whichever order they come
+func NewBox(a, b Point) Box {
+	return Box{
+		Point{min(a.X, b.X), min(a.Y, b.Y)},
+		Point{max(a.X, b.X), max(a.Y, b.Y)},
+	}
+}
+
+// Bounds is the smallest box containing every point given
+func Bounds(p Point, ps ...Point) Box {
+	b := Box{p, p}
+	for _, q := range ps {
+		b = b.Extend(q)
+	}
+	return b
+}
+
+// Width is the number of columns in the box
+func (b Box) Width() int {
+	return b.Max.X - b.Min.X + 1
+}
+
+// Height is the number of rows in the box
+func (b Box) Height() int {
+	return b.Max.Y - b.Min.Y + 1
+}
+
+// Area is the number of points in the box
+func (b Box) Area() int {
+	return b.Width() * b.Height()
+}
+
+// Contains reports whether p is within (or on the edge of) the box
+func (b Box) Contains(p Point) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X && p.Y >= b.Min.Y && p.Y <= b.Max.Y
+}
+
+// Extend grows the box just enough to contain p
+func (b Box) Extend(p Point) Box {
+	return Box{
+		Point{min(b.Min.X, p.X), min(b.Min.Y, p.Y)},
+		Point{max(b.Max.X, p.X), max(b.Max.Y, p.Y)},
+	}
+}
+
+// Union is the smallest box containing both boxes
+func (b Box) Union(o Box) Box {
+	return b.Extend(o.Min).Extend(o.Max)
+}
+
+// Intersect is the box shared by both boxes
+// returning false if they don't overlap
+func (b Box) Intersect(o Box) (Box, bool) {
+	i := Box{
+		Point{max(b.Min.X, o.Min.X), max(b.Min.Y, o.Min.Y)},
+		Point{min(b.Max.X, o.Max.X), min(b.Max.Y, o.Max.Y)},
+	}
+	if i.Min.X > i.Max.X || i.Min.Y > i.Max.Y {
+		return Box{}, false
+	}
+	return i, true
+}
+
+// Box3 is an axis-aligned cuboid, with an inclusive Max
+type Box3 struct {
+	Min, Max Point3
+}
+
+// Bounds3 is the smallest cuboid containing every point given
+func Bounds3(p Point3, ps ...Point3) Box3 {
+	b := Box3{p, p}
+	for _, q := range ps {
+		b = b.Extend(q)
+	}
+	return b
+}
+
+// Volume is the number of points in the cuboid
+func (b Box3) Volume() int {
+	return (b.Max.X - b.Min.X + 1) * (b.Max.Y - b.Min.Y + 1) * (b.Max.Z - b.Min.Z + 1)
+}
+
+// Contains reports whether p is within (or on the surface of) the cuboid
+func (b Box3) Contains(p Point3) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y &&
+		p.Z >= b.Min.Z && p.Z <= b.Max.Z
+}
+
+// Extend grows the cuboid just enough to contain p
+func (b Box3) Extend(p Point3) Box3 {
+	return Box3{
+		Point3{min(b.Min.X, p.X), min(b.Min.Y, p.Y), min(b.Min.Z, p.Z)},
+		Point3{max(b.Max.X, p.X), max(b.Max.Y, p.Y), max(b.Max.Z, p.Z)},
+	}
+}
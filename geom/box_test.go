@@ -0,0 +1,76 @@
+package geom
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBounds(t *testing.T) {
+	b := Bounds(Point{3, 1}, Point{-2, 4}, Point{0, -5})
+
+	expected := Box{Point{-2, -5}, Point{3, 4}}
+	if b != expected {
+		t.Fatalf("Expected %+v, got %+v", expected, b)
+	}
+	if b.Width() != 6 || b.Height() != 10 || b.Area() != 60 {
+		t.Errorf("Expected 6x10=60, got %dx%d=%d", b.Width(), b.Height(), b.Area())
+	}
+
+	if NewBox(Point{3, 4}, Point{-2, -5}) != expected {
+		t.Errorf("Expected NewBox to normalize its corners")
+	}
+}
+
+func TestBoxContains(t *testing.T) {
+	// 2021/17's sample target area
+	b := NewBox(Point{20, -10}, Point{30, -5})
+
+	testCases := []struct {
+		p        Point
+		expected bool
+	}{
+		{Point{20, -10}, true},
+		{Point{30, -5}, true},
+		{Point{25, -7}, true},
+		{Point{19, -7}, false},
+		{Point{25, -4}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%+v", tc.p), func(t *testing.T) {
+			if actual := b.Contains(tc.p); actual != tc.expected {
+				t.Errorf("Expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBoxIntersect(t *testing.T) {
+	a := NewBox(Point{1, 3}, Point{4, 6})
+	b := NewBox(Point{3, 1}, Point{6, 4})
+
+	i, ok := a.Intersect(b)
+	if !ok {
+		t.Fatal("Expected boxes to intersect")
+	} else if expected := NewBox(Point{3, 3}, Point{4, 4}); i != expected {
+		t.Errorf("Expected %+v, got %+v", expected, i)
+	}
+
+	if _, ok := a.Intersect(NewBox(Point{5, 5}, Point{6, 6})); ok {
+		t.Errorf("Did not expect disjoint boxes to intersect")
+	}
+
+	if u := a.Union(b); u != NewBox(Point{1, 1}, Point{6, 6}) {
+		t.Errorf("Expected union to cover both, got %+v", u)
+	}
+}
+
+func TestBox3(t *testing.T) {
+	b := Bounds3(Point3{0, 0, 0}, Point3{1, 2, 3})
+	if b.Volume() != 24 {
+		t.Errorf("Expected volume 24, got %d", b.Volume())
+	}
+	if !b.Contains(Point3{1, 1, 1}) || b.Contains(Point3{2, 1, 1}) {
+		t.Errorf("Unexpected Contains result for %+v", b)
+	}
+}
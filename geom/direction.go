@@ -0,0 +1,112 @@
+package geom
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidAngle = errors.New("Angle is not a multiple of 90 degrees")
+var ErrInvalidDirection = errors.New("Invalid direction")
+
+// Direction is one of the four compass directions
+// they're ordered clockwise, so turning right is just +1
+type Direction int
+
+const (
+	North Direction = iota
+	East
+	South
+	West
+)
+
+// the eight neighbors of the origin, clockwise from North
+var (
+	N  = Point{0, -1}
+	NE = Point{1, -1}
+	E  = Point{1, 0}
+	SE = Point{1, 1}
+	S  = Point{0, 1}
+	SW = Point{-1, 1}
+	W  = Point{-1, 0}
+	NW = Point{-1, -1}
+)
+
+// Cardinals are the four directions, clockwise from North
+var Cardinals = []Direction{North, East, South, West}
+
+func quarterTurns(degrees int) (int, error) {
+	if degrees%90 != 0 {
+		return 0, fmt.Errorf("%w: %d", ErrInvalidAngle, degrees)
+	}
+	// always hand back a clockwise 0-3, no matter how many times around we went
+	return ((degrees/90)%4 + 4) % 4, nil
+}
+
+// ParseDirection understands both compass (NESW) and relative (URDL) letters
+func ParseDirection(r rune) (Direction, error) {
+	switch r {
+	case 'N', 'U', '^':
+		return North, nil
+	case 'E', 'R', '>':
+		return East, nil
+	case 'S', 'D', 'v':
+		return South, nil
+	case 'W', 'L', '<':
+		return West, nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidDirection, r)
+}
+
+// Left is the direction 90 degrees counter-clockwise from d
+func (d Direction) Left() Direction {
+	return (d + 3) % 4
+}
+
+// Right is the direction 90 degrees clockwise from d
+func (d Direction) Right() Direction {
+	return (d + 1) % 4
+}
+
+// Reverse is the direction 180 degrees from d
+func (d Direction) Reverse() Direction {
+	return (d + 2) % 4
+}
+
+// Turn rotates d clockwise by degrees (negative for counter-clockwise)
+// only multiples of 90 are supported
+func (d Direction) Turn(degrees int) (Direction, error) {
+	turns, err := quarterTurns(degrees)
+	if err != nil {
+		return d, err
+	}
+	return (d + Direction(turns)) % 4, nil
+}
+
+// Vector is a single step in direction d
+func (d Direction) Vector() Point {
+	switch d {
+	case North:
+		return N
+	case East:
+		return E
+	case South:
+		return S
+	case West:
+		return W
+	}
+	panic(fmt.Errorf("%w: %d", ErrInvalidDirection, int(d)))
+}
+
+func (d Direction) String() string {
+	switch d {
+	case North:
+		return "N"
+	case East:
+		return "E"
+	case South:
+		return "S"
+	case West:
+		return "W"
+	}
+	return fmt.Sprintf("Direction(%d)", int(d))
+}
@@ -0,0 +1,91 @@
+package geom
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestTurns(t *testing.T) {
+	testCases := []struct {
+		d                    Direction
+		left, right, reverse Direction
+	}{
+		{North, West, East, South},
+		{East, North, South, West},
+		{South, East, West, North},
+		{West, South, North, East},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.d.String(), func(t *testing.T) {
+			if actual := tc.d.Left(); actual != tc.left {
+				t.Errorf("Expected Left %v, got %v", tc.left, actual)
+			}
+			if actual := tc.d.Right(); actual != tc.right {
+				t.Errorf("Expected Right %v, got %v", tc.right, actual)
+			}
+			if actual := tc.d.Reverse(); actual != tc.reverse {
+				t.Errorf("Expected Reverse %v, got %v", tc.reverse, actual)
+			}
+			// the vector and the direction should agree on what turning means
+			if tc.d.Vector().RotateLeft() != tc.left.Vector() {
+				t.Errorf("Expected vector rotation to match direction rotation")
+			}
+		})
+	}
+}
+
+func TestTurn(t *testing.T) {
+	testCases := []struct {
+		degrees  int
+		expected Direction
+		err      error
+	}{
+		{90, South, nil},
+		{-90, North, nil},
+		{270, North, nil},
+		{-270, South, nil},
+		{720, East, nil},
+		{30, East, ErrInvalidAngle},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", tc.degrees), func(t *testing.T) {
+			actual, err := East.Turn(tc.degrees)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected %v but got %v", tc.err, err)
+			}
+			if actual != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseDirection(t *testing.T) {
+	testCases := []struct {
+		r        rune
+		expected Direction
+		err      error
+	}{
+		{'N', North, nil},
+		{'U', North, nil},
+		{'R', East, nil},
+		{'v', South, nil},
+		{'W', West, nil},
+		{'x', 0, ErrInvalidDirection},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%c", tc.r), func(t *testing.T) {
+			actual, err := ParseDirection(tc.r)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected %v but got %v", tc.err, err)
+			}
+			if actual != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
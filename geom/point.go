@@ -0,0 +1,131 @@
+// Package geom holds the integer points, directions and boxes that keep showing up
+//
+// Like our puzzle inputs (and the grid package) y grows downwards, so North is {0, -1}
+package geom
+
+// Point is a 2D point, or the vector between two of them
+type Point struct {
+	X, Y int
+}
+
+// Pt is shorthand for Point{X: x, Y: y}
+func Pt(x, y int) Point {
+	return Point{x, y}
+}
+
+// Origin is where most of our wires, ships and probes start
+var Origin = Point{}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Add returns p moved by q
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+// Sub returns the vector from q to p
+func (p Point) Sub(q Point) Point {
+	return Point{p.X - q.X, p.Y - q.Y}
+}
+
+// Mul scales p by k
+func (p Point) Mul(k int) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+// Neg points p the other way
+func (p Point) Neg() Point {
+	return Point{-p.X, -p.Y}
+}
+
+// Manhattan is the taxicab distance between p and q
+func (p Point) Manhattan(q Point) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y)
+}
+
+// Chebyshev is the chessboard (king moves) distance between p and q
+func (p Point) Chebyshev(q Point) int {
+	return max(abs(p.X-q.X), abs(p.Y-q.Y))
+}
+
+// RotateLeft turns the vector p 90 degrees counter-clockwise around the origin
+func (p Point) RotateLeft() Point {
+	return Point{p.Y, -p.X}
+}
+
+// RotateRight turns the vector p 90 degrees clockwise around the origin
+func (p Point) RotateRight() Point {
+	return Point{-p.Y, p.X}
+}
+
+// Rotate turns the vector p clockwise by degrees (negative for counter-clockwise)
+// only multiples of 90 are supported
+func (p Point) Rotate(degrees int) (Point, error) {
+	turns, err := quarterTurns(degrees)
+	if err != nil {
+		return p, err
+	}
+	for ; turns > 0; turns-- {
+		p = p.RotateRight()
+	}
+	return p, nil
+}
+
+// Point3 is a 3D point, or the vector between two of them
+type Point3 struct {
+	X, Y, Z int
+}
+
+// Pt3 is shorthand for Point3{X: x, Y: y, Z: z}
+func Pt3(x, y, z int) Point3 {
+	return Point3{x, y, z}
+}
+
+// Add returns p moved by q
+func (p Point3) Add(q Point3) Point3 {
+	return Point3{p.X + q.X, p.Y + q.Y, p.Z + q.Z}
+}
+
+// Sub returns the vector from q to p
+func (p Point3) Sub(q Point3) Point3 {
+	return Point3{p.X - q.X, p.Y - q.Y, p.Z - q.Z}
+}
+
+// Mul scales p by k
+func (p Point3) Mul(k int) Point3 {
+	return Point3{p.X * k, p.Y * k, p.Z * k}
+}
+
+// Neg points p the other way
+func (p Point3) Neg() Point3 {
+	return Point3{-p.X, -p.Y, -p.Z}
+}
+
+// Manhattan is the taxicab distance between p and q
+func (p Point3) Manhattan(q Point3) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y) + abs(p.Z-q.Z)
+}
+
+// Chebyshev is the chessboard (king moves) distance between p and q
+func (p Point3) Chebyshev(q Point3) int {
+	return max(max(abs(p.X-q.X), abs(p.Y-q.Y)), abs(p.Z-q.Z))
+}
@@ -0,0 +1,106 @@
+package geom
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPointArithmetic(t *testing.T) {
+	p := Point{3, -4}
+	q := Point{-1, 2}
+
+	if actual := p.Add(q); actual != (Point{2, -2}) {
+		t.Errorf("Expected Add to be {2 -2}, got %+v", actual)
+	}
+	if actual := p.Sub(q); actual != (Point{4, -6}) {
+		t.Errorf("Expected Sub to be {4 -6}, got %+v", actual)
+	}
+	if actual := p.Mul(3); actual != (Point{9, -12}) {
+		t.Errorf("Expected Mul to be {9 -12}, got %+v", actual)
+	}
+	if actual := p.Neg(); actual != (Point{-3, 4}) {
+		t.Errorf("Expected Neg to be {-3 4}, got %+v", actual)
+	}
+}
+
+func TestDistances(t *testing.T) {
+	testCases := []struct {
+		p, q      Point
+		manhattan int
+		chebyshev int
+	}{
+		{Origin, Origin, 0, 0},
+		{Origin, Point{3, 4}, 7, 4},
+		{Point{-3, 4}, Point{2, -1}, 10, 5},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%+v->%+v", tc.p, tc.q), func(t *testing.T) {
+			if actual := tc.p.Manhattan(tc.q); actual != tc.manhattan {
+				t.Errorf("Expected Manhattan %d, got %d", tc.manhattan, actual)
+			}
+			if actual := tc.p.Chebyshev(tc.q); actual != tc.chebyshev {
+				t.Errorf("Expected Chebyshev %d, got %d", tc.chebyshev, actual)
+			}
+			// distances don't care which way we measure
+			if tc.p.Manhattan(tc.q) != tc.q.Manhattan(tc.p) {
+				t.Errorf("Expected Manhattan to be symmetric")
+			}
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	testCases := []struct {
+		degrees  int
+		expected Point
+		err      error
+	}{
+		{0, Point{10, -4}, nil},
+		{90, Point{4, 10}, nil},
+		{180, Point{-10, 4}, nil},
+		{270, Point{-4, -10}, nil},
+		{-90, Point{-4, -10}, nil},
+		{450, Point{4, 10}, nil},
+		{45, Point{10, -4}, ErrInvalidAngle},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", tc.degrees), func(t *testing.T) {
+			// 10 east, 4 north, like 2020/12's waypoint
+			actual, err := Point{10, -4}.Rotate(tc.degrees)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected %v but got %v", tc.err, err)
+			}
+			if actual != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, actual)
+			}
+		})
+	}
+
+	if actual := E.RotateLeft(); actual != N {
+		t.Errorf("Expected East rotated left to be North, got %+v", actual)
+	}
+	if actual := E.RotateRight(); actual != S {
+		t.Errorf("Expected East rotated right to be South, got %+v", actual)
+	}
+}
+
+func TestPoint3(t *testing.T) {
+	p := Point3{1, -2, 3}
+	q := Point3{-4, 5, 6}
+
+	if actual := p.Add(q); actual != (Point3{-3, 3, 9}) {
+		t.Errorf("Expected Add to be {-3 3 9}, got %+v", actual)
+	}
+	if actual := p.Sub(q); actual != (Point3{5, -7, -3}) {
+		t.Errorf("Expected Sub to be {5 -7 -3}, got %+v", actual)
+	}
+	if actual := p.Manhattan(q); actual != 15 {
+		t.Errorf("Expected Manhattan 15, got %d", actual)
+	}
+	if actual := p.Chebyshev(q); actual != 7 {
+		t.Errorf("Expected Chebyshev 7, got %d", actual)
+	}
+}
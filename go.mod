@@ -2,4 +2,4 @@ module gitlab.com/travisby/advent
 
 go 1.18
 
-require golang.org/x/sync v0.0.0-20210220032951-036812b2e83c // indirect
+require golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
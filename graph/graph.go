@@ -0,0 +1,67 @@
+// Package graph searches anything we can describe with a neighbors function
+//
+// nodes just need to be comparable, so grid points, cave names and orbital
+// pointers all fit without building an explicit graph first
+package graph
+
+// Neighbors lists the nodes one (unweighted) step away from n
+type Neighbors[N comparable] func(n N) []N
+
+// Edge is a weighted step to another node
+type Edge[N comparable] struct {
+	To   N
+	Cost int
+}
+
+// WeightedNeighbors lists the edges leaving n
+type WeightedNeighbors[N comparable] func(n N) []Edge[N]
+
+// Result is everything a search learned about the nodes it reached
+type Result[N comparable] struct {
+	dist map[N]int
+	prev map[N]N
+}
+
+func newResult[N comparable]() Result[N] {
+	return Result[N]{dist: make(map[N]int), prev: make(map[N]N)}
+}
+
+// Distance is the cost of the cheapest path from any source to n
+// returning false if n was never reached
+func (r Result[N]) Distance(n N) (int, bool) {
+	d, ok := r.dist[n]
+	return d, ok
+}
+
+// Reached returns every node the search found, sources included
+func (r Result[N]) Reached() []N {
+	nodes := make([]N, 0, len(r.dist))
+	for n := range r.dist {
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// Path is the cheapest path from a source to n, both inclusive
+// returning false if n was never reached
+func (r Result[N]) Path(n N) ([]N, bool) {
+	if _, ok := r.dist[n]; !ok {
+		return nil, false
+	}
+
+	path := []N{n}
+	for {
+		p, ok := r.prev[n]
+		if !ok {
+			break
+		}
+		path = append(path, p)
+		n = p
+	}
+
+	// we walked it backwards
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, true
+}
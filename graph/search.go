@@ -0,0 +1,146 @@
+package graph
+
+import "gitlab.com/travisby/advent/pqueue"
+
+// BFS walks outwards from every source at once, one step at a time
+// so each node's distance is the fewest steps from its nearest source
+func BFS[N comparable](neighbors Neighbors[N], sources ...N) Result[N] {
+	r := newResult[N]()
+
+	queue := make([]N, 0, len(sources))
+	for _, s := range sources {
+		if _, ok := r.dist[s]; !ok {
+			r.dist[s] = 0
+			queue = append(queue, s)
+		}
+	}
+
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+
+		for _, v := range neighbors(u) {
+			if _, ok := r.dist[v]; ok {
+				continue
+			}
+			r.dist[v] = r.dist[u] + 1
+			r.prev[v] = u
+			queue = append(queue, v)
+		}
+	}
+
+	return r
+}
+
+// DFS visits every node reachable from source, going as deep as it can first
+// visit is called once per node in the order they're found, and returning false stops the search
+func DFS[N comparable](neighbors Neighbors[N], source N, visit func(N) bool) {
+	seen := map[N]bool{}
+	stack := []N{source}
+
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if seen[u] {
+			continue
+		}
+		seen[u] = true
+
+		if !visit(u) {
+			return
+		}
+
+		// push in reverse so we explore in the order neighbors gave them to us
+		ns := neighbors(u)
+		for i := len(ns) - 1; i >= 0; i-- {
+			if !seen[ns[i]] {
+				stack = append(stack, ns[i])
+			}
+		}
+	}
+}
+
+// AllPaths enumerates every path from start that ends at a node satisfying isGoal
+//
+// unlike the other searches, nodes may be revisited: next is handed the path so far
+// (ending at the current node) and decides which nodes are eligible to come next
+// so it must eventually run out of options or this will never return
+func AllPaths[N comparable](start N, isGoal func(N) bool, next func(path []N) []N) [][]N {
+	var paths [][]N
+
+	var walk func(path []N)
+	walk = func(path []N) {
+		if isGoal(path[len(path)-1]) {
+			found := make([]N, len(path))
+			copy(found, path)
+			paths = append(paths, found)
+			return
+		}
+
+		for _, n := range next(path) {
+			walk(append(path, n))
+		}
+	}
+	walk([]N{start})
+
+	return paths
+}
+
+// Dijkstra finds the cheapest path from the nearest source to every reachable node
+// costs must not be negative
+func Dijkstra[N comparable](neighbors WeightedNeighbors[N], sources ...N) Result[N] {
+	r, _, _ := search(neighbors, func(N) int { return 0 }, func(N) bool { return false }, sources)
+	return r
+}
+
+// AStar finds the cheapest path from any source to the first node satisfying isGoal
+//
+// heuristic must be consistent: it never overestimates the remaining cost, and never drops
+// by more than an edge's cost across that edge (e.g. Manhattan distance on a grid where every
+// step costs at least 1).  A heuristic of 0 is plain Dijkstra that stops at the first goal
+func AStar[N comparable](neighbors WeightedNeighbors[N], heuristic func(N) int, isGoal func(N) bool, sources ...N) (path []N, cost int, ok bool) {
+	r, goal, ok := search(neighbors, heuristic, isGoal, sources)
+	if !ok {
+		return nil, 0, false
+	}
+
+	path, _ = r.Path(goal)
+	return path, r.dist[goal], true
+}
+
+func search[N comparable](neighbors WeightedNeighbors[N], heuristic func(N) int, isGoal func(N) bool, sources []N) (r Result[N], goal N, found bool) {
+	r = newResult[N]()
+
+	q := pqueue.New[N]()
+	for _, s := range sources {
+		if _, ok := r.dist[s]; !ok {
+			r.dist[s] = 0
+			q.Push(s, heuristic(s))
+		}
+	}
+
+	for {
+		u, _, ok := q.Pop()
+		if !ok {
+			break
+		}
+
+		if isGoal(u) {
+			return r, u, true
+		}
+
+		for _, e := range neighbors(u) {
+			alt := r.dist[u] + e.Cost
+			if d, ok := r.dist[e.To]; ok && d <= alt {
+				continue
+			}
+			r.dist[e.To] = alt
+			r.prev[e.To] = u
+			// either queues it for the first time, or decreases its key
+			q.Push(e.To, alt+heuristic(e.To))
+		}
+	}
+
+	return r, goal, false
+}
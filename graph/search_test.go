@@ -0,0 +1,224 @@
+package graph
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"gitlab.com/travisby/advent/geom"
+	"gitlab.com/travisby/advent/grid"
+)
+
+// 2021/15's sample cave, lowest total risk is 40
+const chitonSample = `1163751742
+1381373672
+2136511328
+3694931569
+7463417111
+1319128137
+1359912421
+3125421639
+1293138521
+2311944581`
+
+func mustParse(t *testing.T, s string) *grid.Grid[int] {
+	t.Helper()
+	g, err := grid.Parse(strings.NewReader(s), func(r rune) (int, error) {
+		return strconv.Atoi(string(r))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func riskEdges(g *grid.Grid[int]) WeightedNeighbors[grid.Point] {
+	return func(p grid.Point) []Edge[grid.Point] {
+		var edges []Edge[grid.Point]
+		for _, n := range g.Neighbors4(p) {
+			edges = append(edges, Edge[grid.Point]{n, g.Get(n)})
+		}
+		return edges
+	}
+}
+
+// an undirected graph by name, like 2021/12's caves
+type adjacency map[string][]string
+
+func newAdjacency(edges string) adjacency {
+	a := adjacency{}
+	for _, e := range strings.Fields(edges) {
+		splits := strings.Split(e, "-")
+		a[splits[0]] = append(a[splits[0]], splits[1])
+		a[splits[1]] = append(a[splits[1]], splits[0])
+	}
+	return a
+}
+
+func (a adjacency) neighbors(n string) []string {
+	return a[n]
+}
+
+func pathsEqual[N comparable](a, b []N) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBFS(t *testing.T) {
+	a := newAdjacency("a-b b-c c-d a-e e-d d-f")
+
+	r := BFS(a.neighbors, "a")
+	testCases := []struct {
+		node string
+		dist int
+	}{
+		{"a", 0},
+		{"b", 1},
+		{"e", 1},
+		{"d", 2},
+		{"f", 3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.node, func(t *testing.T) {
+			if d, ok := r.Distance(tc.node); !ok || d != tc.dist {
+				t.Errorf("Expected distance %d, got (%d, %t)", tc.dist, d, ok)
+			}
+		})
+	}
+
+	if path, ok := r.Path("f"); !ok || !pathsEqual(path, []string{"a", "e", "d", "f"}) {
+		t.Errorf("Expected path a-e-d-f, got %+v", path)
+	}
+
+	if _, ok := r.Distance("z"); ok {
+		t.Errorf("Did not expect to reach z")
+	}
+	if _, ok := r.Path("z"); ok {
+		t.Errorf("Did not expect a path to z")
+	}
+}
+
+func TestBFSMultiSource(t *testing.T) {
+	a := newAdjacency("a-b b-c c-d d-e")
+
+	r := BFS(a.neighbors, "a", "e")
+	if d, _ := r.Distance("c"); d != 2 {
+		t.Errorf("Expected c to be 2 from its nearest source, got %d", d)
+	}
+	if d, _ := r.Distance("d"); d != 1 {
+		t.Errorf("Expected d to be 1 from its nearest source, got %d", d)
+	}
+	if path, _ := r.Path("d"); !pathsEqual(path, []string{"e", "d"}) {
+		t.Errorf("Expected path to start at the nearest source, got %+v", path)
+	}
+}
+
+func TestDFS(t *testing.T) {
+	a := newAdjacency("a-b b-c a-d d-e")
+
+	var order []string
+	DFS(a.neighbors, "a", func(n string) bool {
+		order = append(order, n)
+		return true
+	})
+	if !pathsEqual(order, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("Expected depth first order, got %+v", order)
+	}
+
+	order = nil
+	DFS(a.neighbors, "a", func(n string) bool {
+		order = append(order, n)
+		return n != "c"
+	})
+	if !pathsEqual(order, []string{"a", "b", "c"}) {
+		t.Errorf("Expected to stop at c, got %+v", order)
+	}
+}
+
+func TestAllPaths(t *testing.T) {
+	// 2021/12's first sample, small caves can only be visited once
+	a := newAdjacency("start-A start-b A-c A-b b-d A-end b-end")
+
+	paths := AllPaths("start", func(n string) bool { return n == "end" }, func(path []string) []string {
+		var next []string
+		for _, n := range a.neighbors(path[len(path)-1]) {
+			visited := false
+			for _, p := range path {
+				visited = visited || p == n
+			}
+			if n == strings.ToUpper(n) || !visited {
+				next = append(next, n)
+			}
+		}
+		return next
+	})
+
+	if len(paths) != 10 {
+		t.Errorf("Expected 10 paths, got %d: %+v", len(paths), paths)
+	}
+	for _, p := range paths {
+		if p[0] != "start" || p[len(p)-1] != "end" {
+			t.Errorf("Expected every path to go start->end, got %+v", p)
+		}
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	g := mustParse(t, chitonSample)
+	end := geom.Pt(g.Width()-1, g.Height()-1)
+
+	r := Dijkstra(riskEdges(g), geom.Origin)
+	if d, ok := r.Distance(end); !ok || d != 40 {
+		t.Fatalf("Expected lowest total risk of 40, got (%d, %t)", d, ok)
+	}
+
+	path, _ := r.Path(end)
+	if path[0] != geom.Origin || path[len(path)-1] != end {
+		t.Fatalf("Expected path from origin to end, got %+v", path)
+	}
+
+	// the path should actually add up to its distance
+	var risk int
+	for _, p := range path[1:] {
+		risk += g.Get(p)
+	}
+	if risk != 40 {
+		t.Errorf("Expected path to total 40, got %d", risk)
+	}
+}
+
+func TestAStar(t *testing.T) {
+	g := mustParse(t, chitonSample)
+	end := geom.Pt(g.Width()-1, g.Height()-1)
+
+	testCases := []struct {
+		name      string
+		heuristic func(grid.Point) int
+	}{
+		{"Zero", func(grid.Point) int { return 0 }},
+		{"Manhattan", func(p grid.Point) int { return p.Manhattan(end) }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path, cost, ok := AStar(riskEdges(g), tc.heuristic, func(p grid.Point) bool { return p == end }, geom.Origin)
+			if !ok || cost != 40 {
+				t.Fatalf("Expected cost of 40, got (%d, %t)", cost, ok)
+			}
+			if path[len(path)-1] != end {
+				t.Errorf("Expected path to finish at the end, got %+v", path)
+			}
+		})
+	}
+
+	if _, _, ok := AStar(riskEdges(g), testCases[0].heuristic, func(p grid.Point) bool { return p.X < 0 }, geom.Origin); ok {
+		t.Errorf("Did not expect to find an unreachable goal")
+	}
+}
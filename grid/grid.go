@@ -0,0 +1,232 @@
+// Package grid is a generic 2D grid shared between the days that need one
+//
+// (0, 0) is the top-left cell, x grows to the right and y grows downwards,
+// which matches the order the cells show up in our puzzle inputs (and the geom package)
+package grid
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gitlab.com/travisby/advent/geom"
+)
+
+var ErrRagged = errors.New("Rows are not all the same width")
+var ErrOutOfBounds = errors.New("Point is outside of the grid")
+
+// Point is a single cell's coordinates within a grid
+type Point = geom.Point
+
+// The compass directions, as a single step away from the origin
+var (
+	North     = geom.N
+	NorthEast = geom.NE
+	East      = geom.E
+	SouthEast = geom.SE
+	South     = geom.S
+	SouthWest = geom.SW
+	West      = geom.W
+	NorthWest = geom.NW
+)
+
+// Orthogonal are the four directions that share an edge with a cell
+var Orthogonal = []Point{North, East, South, West}
+
+// Diagonal are the four directions that share only a corner with a cell
+var Diagonal = []Point{NorthEast, SouthEast, SouthWest, NorthWest}
+
+// All eight directions surrounding a cell, clockwise from North
+var All = []Point{North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest}
+
+// View is anything we can look cells up in
+// a Grid is a View, but so are the wrapping and tiling views on top of one
+type View[T any] interface {
+	Get(p Point) T
+	InBounds(p Point) bool
+}
+
+// Grid is a rectangular grid of cells, stored row-major
+type Grid[T any] struct {
+	cells  []T
+	width  int
+	height int
+}
+
+// New creates a width*height grid full of T's zero value
+func New[T any](width, height int) *Grid[T] {
+	return &Grid[T]{cells: make([]T, width*height), width: width, height: height}
+}
+
+// Parse reads a block of lines, one row per line, converting each rune with fn
+// every row must be the same width
+func Parse[T any](r io.Reader, fn func(rune) (T, error)) (*Grid[T], error) {
+	g := &Grid[T]{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		row := []rune(scanner.Text())
+		if g.height == 0 {
+			g.width = len(row)
+		} else if len(row) != g.width {
+			return nil, fmt.Errorf("%w: line %d has %d cells, expected %d", ErrRagged, g.height+1, len(row), g.width)
+		}
+
+		for i, c := range row {
+			v, err := fn(c)
+			if err != nil {
+				return nil, fmt.Errorf("line %d col %d: %w", g.height+1, i+1, err)
+			}
+			g.cells = append(g.cells, v)
+		}
+		g.height++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// Width is the number of columns
+func (g *Grid[T]) Width() int {
+	return g.width
+}
+
+// Height is the number of rows
+func (g *Grid[T]) Height() int {
+	return g.height
+}
+
+// InBounds reports whether p is a cell within the grid
+func (g *Grid[T]) InBounds(p Point) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+// Get returns the value at p
+// like a slice, it panics when p is out of bounds
+func (g *Grid[T]) Get(p Point) T {
+	if !g.InBounds(p) {
+		panic(fmt.Errorf("%w: %+v", ErrOutOfBounds, p))
+	}
+	return g.cells[p.Y*g.width+p.X]
+}
+
+// Set stores v at p
+// like a slice, it panics when p is out of bounds
+func (g *Grid[T]) Set(p Point, v T) {
+	if !g.InBounds(p) {
+		panic(fmt.Errorf("%w: %+v", ErrOutOfBounds, p))
+	}
+	g.cells[p.Y*g.width+p.X] = v
+}
+
+// Points returns every point in the grid in reading order
+func (g *Grid[T]) Points() []Point {
+	points := make([]Point, 0, len(g.cells))
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			points = append(points, geom.Pt(x, y))
+		}
+	}
+	return points
+}
+
+// Count returns the number of cells that satisfy pred
+func (g *Grid[T]) Count(pred func(T) bool) int {
+	var count int
+	for _, v := range g.cells {
+		if pred(v) {
+			count++
+		}
+	}
+	return count
+}
+
+// Clone is a deep copy of the grid
+func (g *Grid[T]) Clone() *Grid[T] {
+	cells := make([]T, len(g.cells))
+	copy(cells, g.cells)
+	return &Grid[T]{cells: cells, width: g.width, height: g.height}
+}
+
+// Neighbors4 are the in-bounds points sharing an edge with p
+func (g *Grid[T]) Neighbors4(p Point) []Point {
+	return Neighbors4[T](g, p)
+}
+
+// Neighbors8 are the in-bounds points sharing an edge or corner with p
+func (g *Grid[T]) Neighbors8(p Point) []Point {
+	return Neighbors8[T](g, p)
+}
+
+// Cast walks from p (exclusive) in direction d until pred is satisfied
+func (g *Grid[T]) Cast(p, d Point, pred func(T) bool) (Point, bool) {
+	return Cast[T](g, p, d, pred)
+}
+
+// Render draws the grid one row per line, using fn to decide what each cell looks like
+func (g *Grid[T]) Render(fn func(T) rune) string {
+	var b strings.Builder
+	b.Grow(g.height * (g.width + 1))
+	for y := 0; y < g.height; y++ {
+		if y != 0 {
+			b.WriteRune('\n')
+		}
+		for x := 0; x < g.width; x++ {
+			b.WriteRune(fn(g.cells[y*g.width+x]))
+		}
+	}
+	return b.String()
+}
+
+// String() draws the grid with each cell formatted by fmt
+// this is mostly useful when T is a fmt.Stringer that returns a single character
+func (g *Grid[T]) String() string {
+	var b strings.Builder
+	for y := 0; y < g.height; y++ {
+		if y != 0 {
+			b.WriteRune('\n')
+		}
+		for x := 0; x < g.width; x++ {
+			fmt.Fprint(&b, g.cells[y*g.width+x])
+		}
+	}
+	return b.String()
+}
+
+func neighbors[T any](v View[T], p Point, directions []Point) []Point {
+	results := make([]Point, 0, len(directions))
+	for _, d := range directions {
+		if n := p.Add(d); v.InBounds(n) {
+			results = append(results, n)
+		}
+	}
+	return results
+}
+
+// Neighbors4 are the in-bounds points sharing an edge with p
+func Neighbors4[T any](v View[T], p Point) []Point {
+	return neighbors(v, p, Orthogonal)
+}
+
+// Neighbors8 are the in-bounds points sharing an edge or corner with p
+func Neighbors8[T any](v View[T], p Point) []Point {
+	return neighbors(v, p, All)
+}
+
+// Cast walks from p (exclusive) in direction d until it finds a cell satisfying pred
+// returning false if it walks off of the view first
+//
+// d must not be the zero Point; on a view that wraps along d's axis this never
+// walks off, so make sure pred will be satisfied eventually
+func Cast[T any](v View[T], p, d Point, pred func(T) bool) (Point, bool) {
+	for p = p.Add(d); v.InBounds(p); p = p.Add(d) {
+		if pred(v.Get(p)) {
+			return p, true
+		}
+	}
+	return p, false
+}
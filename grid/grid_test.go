@@ -0,0 +1,257 @@
+package grid
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"gitlab.com/travisby/advent/geom"
+)
+
+func parseDigit(r rune) (int, error) {
+	return strconv.Atoi(string(r))
+}
+
+func mustParse(t *testing.T, s string) *Grid[int] {
+	t.Helper()
+	g, err := Parse(strings.NewReader(s), parseDigit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func pointsEqual(a, b []Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		width  int
+		height int
+		err    error
+	}{
+		{"Empty", "", 0, 0, nil},
+		{"Single", "1", 1, 1, nil},
+		{"Rectangle", "123\n456", 3, 2, nil},
+		{"Ragged", "123\n45", 0, 0, ErrRagged},
+		{"Bad Rune", "12\n4x", 0, 0, strconv.ErrSyntax},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := Parse(strings.NewReader(tc.input), parseDigit)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected %v but got %v", tc.err, err)
+			} else if err != nil {
+				return
+			}
+
+			if g.Width() != tc.width || g.Height() != tc.height {
+				t.Errorf("Expected %dx%d, got %dx%d", tc.width, tc.height, g.Width(), g.Height())
+			}
+		})
+	}
+}
+
+func TestParseErrorPosition(t *testing.T) {
+	_, err := Parse(strings.NewReader("12\n4x"), parseDigit)
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2 col 2:") {
+		t.Errorf("Expected error to point at line 2 col 2, got %v", err)
+	}
+}
+
+func TestGetSet(t *testing.T) {
+	g := mustParse(t, "123\n456")
+
+	if v := g.Get(geom.Pt(2, 1)); v != 6 {
+		t.Errorf("Expected (2, 1) to be 6, got %d", v)
+	}
+
+	g.Set(geom.Pt(0, 1), 9)
+	if v := g.Get(geom.Pt(0, 1)); v != 9 {
+		t.Errorf("Expected (0, 1) to be 9, got %d", v)
+	}
+}
+
+func TestGetOutOfBoundsPanics(t *testing.T) {
+	g := New[int](2, 2)
+
+	defer func() {
+		r := recover()
+		if err, ok := r.(error); !ok || !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("Expected a panic with ErrOutOfBounds, got %v", r)
+		}
+	}()
+	g.Get(geom.Pt(2, 0))
+}
+
+func TestNeighbors(t *testing.T) {
+	g := New[int](3, 3)
+
+	testCases := []struct {
+		p  Point
+		n4 []Point
+		n8 []Point
+	}{
+		{
+			geom.Pt(0, 0),
+			[]Point{geom.Pt(1, 0), geom.Pt(0, 1)},
+			[]Point{geom.Pt(1, 0), geom.Pt(1, 1), geom.Pt(0, 1)},
+		},
+		{
+			geom.Pt(1, 1),
+			[]Point{geom.Pt(1, 0), geom.Pt(2, 1), geom.Pt(1, 2), geom.Pt(0, 1)},
+			[]Point{geom.Pt(1, 0), geom.Pt(2, 0), geom.Pt(2, 1), geom.Pt(2, 2), geom.Pt(1, 2), geom.Pt(0, 2), geom.Pt(0, 1), geom.Pt(0, 0)},
+		},
+		{
+			geom.Pt(2, 1),
+			[]Point{geom.Pt(2, 0), geom.Pt(2, 2), geom.Pt(1, 1)},
+			[]Point{geom.Pt(2, 0), geom.Pt(2, 2), geom.Pt(1, 2), geom.Pt(1, 1), geom.Pt(1, 0)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%+v", tc.p), func(t *testing.T) {
+			if actual := g.Neighbors4(tc.p); !pointsEqual(actual, tc.n4) {
+				t.Errorf("Expected Neighbors4 %+v, got %+v", tc.n4, actual)
+			}
+			if actual := g.Neighbors8(tc.p); !pointsEqual(actual, tc.n8) {
+				t.Errorf("Expected Neighbors8 %+v, got %+v", tc.n8, actual)
+			}
+		})
+	}
+}
+
+func TestCast(t *testing.T) {
+	g := mustParse(t, "10002\n00000\n00003")
+	nonZero := func(v int) bool { return v != 0 }
+
+	testCases := []struct {
+		name  string
+		from  Point
+		d     Point
+		to    Point
+		found bool
+	}{
+		{"East hits the 2", geom.Pt(0, 0), East, geom.Pt(4, 0), true},
+		{"West hits the 1", geom.Pt(3, 0), West, geom.Pt(0, 0), true},
+		{"Start is not included", geom.Pt(0, 0), South, geom.Pt(0, 3), false},
+		{"Diagonal", geom.Pt(2, 0), SouthEast, geom.Pt(4, 2), true},
+		{"Walks off", geom.Pt(2, 1), North, geom.Pt(2, -1), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, found := g.Cast(tc.from, tc.d, nonZero)
+			if found != tc.found || p != tc.to {
+				t.Errorf("Expected (%+v, %t), got (%+v, %t)", tc.to, tc.found, p, found)
+			}
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	g := mustParse(t, "101\n011")
+	if c := g.Count(func(v int) bool { return v == 1 }); c != 4 {
+		t.Errorf("Expected 4 ones, got %d", c)
+	}
+}
+
+func TestClone(t *testing.T) {
+	a := mustParse(t, "12\n34")
+	b := a.Clone()
+	b.Set(geom.Pt(0, 0), 9)
+
+	if a.Get(geom.Pt(0, 0)) != 1 {
+		t.Errorf("Expected clone to not affect the original")
+	}
+}
+
+func TestRender(t *testing.T) {
+	g := New[bool](3, 2)
+	g.Set(geom.Pt(1, 0), true)
+	g.Set(geom.Pt(2, 1), true)
+
+	expected := ".#.\n..#"
+	actual := g.Render(func(b bool) rune {
+		if b {
+			return '#'
+		}
+		return '.'
+	})
+	if actual != expected {
+		t.Errorf("Expected %q, got %q", expected, actual)
+	}
+}
+
+func TestString(t *testing.T) {
+	input := "123\n456"
+	if s := mustParse(t, input).String(); s != input {
+		t.Errorf("Expected %q, got %q", input, s)
+	}
+}
+
+func TestWrapped(t *testing.T) {
+	g := mustParse(t, "12\n34")
+	w := g.Wrap(true, false)
+
+	testCases := []struct {
+		p        Point
+		inBounds bool
+		v        int
+	}{
+		{geom.Pt(0, 0), true, 1},
+		{geom.Pt(5, 0), true, 2},
+		{geom.Pt(-1, 1), true, 4},
+		{geom.Pt(0, 2), false, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%+v", tc.p), func(t *testing.T) {
+			if w.InBounds(tc.p) != tc.inBounds {
+				t.Fatalf("Expected InBounds %t", tc.inBounds)
+			}
+			if tc.inBounds && w.Get(tc.p) != tc.v {
+				t.Errorf("Expected %d, got %d", tc.v, w.Get(tc.p))
+			}
+		})
+	}
+
+	if n := Neighbors4[int](w, geom.Pt(0, 0)); !pointsEqual(n, []Point{geom.Pt(1, 0), geom.Pt(0, 1), geom.Pt(-1, 0)}) {
+		t.Errorf("Expected wrapping neighbors, got %+v", n)
+	}
+}
+
+func TestTiled(t *testing.T) {
+	g := mustParse(t, "8")
+	// the 2021/15 rule: every tile right or down is one riskier, wrapping 9 back to 1
+	tiled := g.Tile(3, 2, func(v int, tile Point) int {
+		return (v+tile.X+tile.Y-1)%9 + 1
+	})
+
+	if tiled.Width() != 3 || tiled.Height() != 2 {
+		t.Fatalf("Expected 3x2, got %dx%d", tiled.Width(), tiled.Height())
+	}
+
+	expected := "891\n912"
+	if actual := tiled.Grid().String(); actual != expected {
+		t.Errorf("Expected %q, got %q", expected, actual)
+	}
+
+	if tiled.InBounds(geom.Pt(3, 0)) {
+		t.Errorf("Did not expect (3, 0) to be in bounds")
+	}
+}
@@ -0,0 +1,91 @@
+package grid
+
+import "gitlab.com/travisby/advent/geom"
+
+// Wrapped repeats a grid infinitely along the chosen axes
+// e.g. 2020/03's forest repeats forever to the right
+type Wrapped[T any] struct {
+	g          *Grid[T]
+	horizontal bool
+	vertical   bool
+}
+
+// Wrap creates a view of g that repeats horizontally and/or vertically
+func (g *Grid[T]) Wrap(horizontal, vertical bool) Wrapped[T] {
+	return Wrapped[T]{g, horizontal, vertical}
+}
+
+func mod(a, b int) int {
+	return ((a % b) + b) % b
+}
+
+func (w Wrapped[T]) wrap(p Point) Point {
+	if w.horizontal && w.g.width > 0 {
+		p.X = mod(p.X, w.g.width)
+	}
+	if w.vertical && w.g.height > 0 {
+		p.Y = mod(p.Y, w.g.height)
+	}
+	return p
+}
+
+// InBounds reports whether p lands on the underlying grid once wrapped
+func (w Wrapped[T]) InBounds(p Point) bool {
+	return w.g.InBounds(w.wrap(p))
+}
+
+// Get returns the value at p once wrapped
+func (w Wrapped[T]) Get(p Point) T {
+	return w.g.Get(w.wrap(p))
+}
+
+// Tiled repeats a grid a fixed number of times in each direction
+// letting each copy transform the original values based on which tile it is
+// e.g. 2021/15's cave is five tiles wide, with risk growing every tile
+type Tiled[T any] struct {
+	g      *Grid[T]
+	across int
+	down   int
+	fn     func(v T, tile Point) T
+}
+
+// Tile creates a view of g repeated across*down times
+// fn receives the original value and the tile it's in, with (0, 0) being the original grid
+func (g *Grid[T]) Tile(across, down int, fn func(v T, tile Point) T) Tiled[T] {
+	return Tiled[T]{g, across, down, fn}
+}
+
+// Width is the number of columns across every tile
+func (t Tiled[T]) Width() int {
+	return t.g.width * t.across
+}
+
+// Height is the number of rows across every tile
+func (t Tiled[T]) Height() int {
+	return t.g.height * t.down
+}
+
+// InBounds reports whether p is within one of the tiles
+func (t Tiled[T]) InBounds(p Point) bool {
+	return p.X >= 0 && p.X < t.Width() && p.Y >= 0 && p.Y < t.Height()
+}
+
+// Get returns the transformed value at p
+func (t Tiled[T]) Get(p Point) T {
+	if !t.InBounds(p) {
+		// let the grid do our panicking for us
+		return t.g.Get(p)
+	}
+
+	tile := geom.Pt(p.X/t.g.width, p.Y/t.g.height)
+	return t.fn(t.g.Get(geom.Pt(p.X%t.g.width, p.Y%t.g.height)), tile)
+}
+
+// Grid copies every tile out into its own Grid
+func (t Tiled[T]) Grid() *Grid[T] {
+	g := New[T](t.Width(), t.Height())
+	for _, p := range g.Points() {
+		g.Set(p, t.Get(p))
+	}
+	return g
+}
@@ -0,0 +1,117 @@
+package input
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var ErrShortBlock = errors.New("Input ended partway through a block")
+var ErrFieldCount = errors.New("Unexpected number of fields")
+var ErrSectionCount = errors.New("Unexpected number of sections")
+
+// ParseError says where in the input something went wrong
+type ParseError struct {
+	Unit string // "line" or "token"
+	N    int    // which line/token, starting from 1
+	Text string // the offending text
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s %d (%q): %v", e.Unit, e.N, e.Text, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseLines converts every line of r with fn
+func ParseLines[T any](r io.Reader, fn func(string) (T, error)) ([]T, error) {
+	return parse(r, bufio.ScanLines, "line", 1, fn)
+}
+
+// ParseTokens converts every token split out of r with fn
+// e.g. ParseTokens(r, ScanCommas, strconv.Atoi) for an intcode program
+func ParseTokens[T any](r io.Reader, split bufio.SplitFunc, fn func(string) (T, error)) ([]T, error) {
+	return parse(r, split, "token", 1, fn)
+}
+
+// Ints is ParseTokens(r, ScanCommas, strconv.Atoi), which is about half of the inputs out there
+func Ints(r io.Reader) ([]int, error) {
+	return ParseTokens(r, ScanCommas, strconv.Atoi)
+}
+
+func parse[T any](r io.Reader, split bufio.SplitFunc, unit string, first int, fn func(string) (T, error)) ([]T, error) {
+	var results []T
+
+	scanner := bufio.NewScanner(r)
+	scanner.Split(split)
+	for n := first; scanner.Scan(); n++ {
+		v, err := fn(scanner.Text())
+		if err != nil {
+			return nil, &ParseError{unit, n, scanner.Text(), err}
+		}
+		results = append(results, v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Sscanf is fmt.Sscanf, but a partial match is an error too
+func Sscanf(s string, format string, args ...interface{}) error {
+	n, err := fmt.Sscanf(s, format, args...)
+	if err != nil {
+		return err
+	} else if n != len(args) {
+		return fmt.Errorf("%w: expected %d, got %d", ErrFieldCount, len(args), n)
+	}
+	return nil
+}
+
+// Section is one blank-line separated part of a multi-part input
+// e.g. 2021/13's dots and then its folds
+type Section struct {
+	Start int // the line number of the section's first line
+	Lines []string
+}
+
+// Sections splits r on blank lines
+// if n isn't negative, exactly n sections are expected
+func Sections(r io.Reader, n int) ([]Section, error) {
+	var sections []Section
+	var cur *Section
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if scanner.Text() == "" {
+			cur = nil
+			continue
+		}
+		if cur == nil {
+			sections = append(sections, Section{Start: line})
+			cur = &sections[len(sections)-1]
+		}
+		cur.Lines = append(cur.Lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if n >= 0 && len(sections) != n {
+		return nil, fmt.Errorf("%w: expected %d, got %d", ErrSectionCount, n, len(sections))
+	}
+	return sections, nil
+}
+
+// ParseSection converts every line of s with fn
+// errors carry the line number within the whole input, not just the section
+func ParseSection[T any](s Section, fn func(string) (T, error)) ([]T, error) {
+	return parse(strings.NewReader(strings.Join(s.Lines, "\n")), bufio.ScanLines, "line", s.Start, fn)
+}
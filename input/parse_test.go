@@ -0,0 +1,106 @@
+package input
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func intSliceEquals(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestParseLines(t *testing.T) {
+	actual, err := ParseLines(strings.NewReader("1\n2\n3\n"), strconv.Atoi)
+	if err != nil {
+		t.Fatal(err)
+	} else if !intSliceEquals(actual, []int{1, 2, 3}) {
+		t.Fatalf("Expected [1 2 3], got %+v", actual)
+	}
+}
+
+func TestParseLinesError(t *testing.T) {
+	_, err := ParseLines(strings.NewReader("1\n2\nthree\n"), strconv.Atoi)
+
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("Expected a ParseError, got %v", err)
+	}
+	if pe.Unit != "line" || pe.N != 3 || pe.Text != "three" {
+		t.Errorf("Expected error on line 3 (three), got %+v", *pe)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Expected to be able to unwrap down to the strconv error, got %v", err)
+	}
+}
+
+func TestInts(t *testing.T) {
+	actual, err := Ints(strings.NewReader("3,4,3,1,2\n"))
+	if err != nil {
+		t.Fatal(err)
+	} else if !intSliceEquals(actual, []int{3, 4, 3, 1, 2}) {
+		t.Fatalf("Expected [3 4 3 1 2], got %+v", actual)
+	}
+
+	_, err = Ints(strings.NewReader("3,x,3"))
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Unit != "token" || pe.N != 2 {
+		t.Errorf("Expected an error on token 2, got %v", err)
+	}
+}
+
+func TestSscanf(t *testing.T) {
+	var x, y int
+	if err := Sscanf("6,10", "%d,%d", &x, &y); err != nil {
+		t.Fatal(err)
+	} else if x != 6 || y != 10 {
+		t.Errorf("Expected (6, 10), got (%d, %d)", x, y)
+	}
+
+	if err := Sscanf("6,", "%d,%d", &x, &y); err == nil {
+		t.Errorf("Expected a partial match to be an error")
+	}
+}
+
+func TestSections(t *testing.T) {
+	// a trimmed down 2021/13
+	input := "6,10\n0,14\n\nfold along y=7\nfold along x=5\n"
+
+	sections, err := Sections(strings.NewReader(input), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sections[0].Start != 1 || !stringSliceEquals(sections[0].Lines, []string{"6,10", "0,14"}) {
+		t.Errorf("Unexpected first section %+v", sections[0])
+	}
+	if sections[1].Start != 4 || !stringSliceEquals(sections[1].Lines, []string{"fold along y=7", "fold along x=5"}) {
+		t.Errorf("Unexpected second section %+v", sections[1])
+	}
+
+	if _, err := Sections(strings.NewReader(input), 3); !errors.Is(err, ErrSectionCount) {
+		t.Errorf("Expected ErrSectionCount, got %v", err)
+	}
+	if s, err := Sections(strings.NewReader(input), -1); err != nil || len(s) != 2 {
+		t.Errorf("Expected any number of sections to be allowed, got (%d, %v)", len(s), err)
+	}
+}
+
+func TestParseSection(t *testing.T) {
+	s := Section{Start: 4, Lines: []string{"1", "x"}}
+
+	_, err := ParseSection(s, strconv.Atoi)
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.N != 5 {
+		t.Errorf("Expected an error on line 5 of the whole input, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+// Package input is the reading boilerplate every day ends up needing
+//
+// split functions for bufio.Scanner, typed parsing that says which line was bad,
+// and splitting multi-part inputs into their sections
+package input
+
+import (
+	"bufio"
+	"bytes"
+)
+
+// ScanCommas is a bufio.SplitFunc like bufio.ScanWords, but with "," instead of " " as the delimiter
+// surrounding whitespace (e.g. the trailing newline) is trimmed from each token
+// and empty tokens are skipped
+func ScanCommas(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	for {
+		i := bytes.IndexByte(data[advance:], ',')
+		if i == -1 {
+			break
+		}
+
+		token = bytes.TrimSpace(data[advance : advance+i])
+		advance += i + 1
+		if len(token) > 0 {
+			return advance, token, nil
+		}
+	}
+
+	// If we're at EOF, we have a final, non-terminated token. Return it (if it's not just whitespace)
+	if atEOF {
+		if token = bytes.TrimSpace(data[advance:]); len(token) > 0 {
+			return len(data), token, nil
+		}
+		return len(data), nil, nil
+	}
+
+	// Request more data, but let go of any empty tokens we already skipped
+	return advance, nil, nil
+}
+
+// ScanGroups is a bufio.SplitFunc that returns blank-line separated groups of lines
+// e.g. 2020/04's passports or 2020/06's customs answers
+// the newlines between lines of a group are kept, but the trailing ones are not
+func ScanGroups(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	// skip any blank lines before the group starts
+	start := 0
+	for start < len(data) && (data[start] == '\n' || data[start] == '\r') {
+		start++
+	}
+
+	for i := start; i < len(data); {
+		nl := bytes.IndexByte(data[i:], '\n')
+		if nl == -1 {
+			break
+		}
+		lineEnd := i + nl
+		next := lineEnd + 1
+
+		// is the next line blank?
+		j := next
+		if j < len(data) && data[j] == '\r' {
+			j++
+		}
+		if j < len(data) && data[j] == '\n' {
+			return j + 1, bytes.TrimRight(data[start:lineEnd], "\r"), nil
+		}
+		i = next
+	}
+
+	if atEOF {
+		if token := bytes.TrimRight(data[start:], "\r\n"); len(token) > 0 {
+			return len(data), token, nil
+		}
+		return len(data), nil, nil
+	}
+
+	// Request more data.
+	return start, nil, nil
+}
+
+// ScanFixedWidth creates a bufio.SplitFunc that returns blocks of exactly n bytes
+// newlines are ignored entirely, so a block can span lines (e.g. 2019/08's image layers)
+// a short final block is an error
+func ScanFixedWidth(n int) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		token = make([]byte, 0, n)
+		for advance = 0; advance < len(data) && len(token) < n; advance++ {
+			if data[advance] != '\n' && data[advance] != '\r' {
+				token = append(token, data[advance])
+			}
+		}
+
+		if len(token) == n {
+			return advance, token, nil
+		} else if atEOF && len(token) > 0 {
+			return 0, nil, ErrShortBlock
+		} else if atEOF {
+			return len(data), nil, nil
+		}
+
+		// Request more data.
+		return 0, nil, nil
+	}
+}
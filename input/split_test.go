@@ -0,0 +1,112 @@
+package input
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func stringSliceEquals(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// testing a split func directly is a pain
+// so wrap it in a scanner and make sure _that_ works ok!
+// everything goes through a OneByteReader too, so we know partial reads work
+func scanAll(input string, split bufio.SplitFunc) ([]string, error) {
+	scanner := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(input)))
+	scanner.Split(split)
+
+	tokens := []string{}
+	for scanner.Scan() {
+		tokens = append(tokens, scanner.Text())
+	}
+	return tokens, scanner.Err()
+}
+
+func TestScanCommas(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		output []string
+	}{
+		{"Empty", "", []string{}},
+		{"Single", "1", []string{"1"}},
+		{"Intcode", "1,9,10,3\n", []string{"1", "9", "10", "3"}},
+		{"Spaces", " 3, 4 ,5", []string{"3", "4", "5"}},
+		{"Empty Tokens", ",1,,2,\n", []string{"1", "2"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := scanAll(tc.input, ScanCommas)
+			if err != nil {
+				t.Fatal(err)
+			} else if !stringSliceEquals(actual, tc.output) {
+				t.Fatalf("Expected (%q) to match (%q)", actual, tc.output)
+			}
+		})
+	}
+}
+
+func TestScanGroups(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		output []string
+	}{
+		{"Empty", "", []string{}},
+		{"One Group", "abc\n", []string{"abc"}},
+		{"Customs", "abc\n\na\nb\nc\n\nab\nac\n", []string{"abc", "a\nb\nc", "ab\nac"}},
+		{"No Trailing Newline", "a\n\nb", []string{"a", "b"}},
+		{"Extra Blank Lines", "\n\na\n\n\n\nb\n\n", []string{"a", "b"}},
+		{"CRLF", "a\r\nb\r\n\r\nc\r\n", []string{"a\r\nb", "c"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := scanAll(tc.input, ScanGroups)
+			if err != nil {
+				t.Fatal(err)
+			} else if !stringSliceEquals(actual, tc.output) {
+				t.Fatalf("Expected (%q) to match (%q)", actual, tc.output)
+			}
+		})
+	}
+}
+
+func TestScanFixedWidth(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		width  int
+		output []string
+		err    error
+	}{
+		{"Empty", "", 3, []string{}, nil},
+		{"SIF", "123456789012\n", 6, []string{"123456", "789012"}, nil},
+		{"Across Lines", "12\n34\n56\n", 3, []string{"123", "456"}, nil},
+		{"Short", "1234\n", 3, []string{"123"}, ErrShortBlock},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := scanAll(tc.input, ScanFixedWidth(tc.width))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected %v but got %v", tc.err, err)
+			} else if !stringSliceEquals(actual, tc.output) {
+				t.Fatalf("Expected (%q) to match (%q)", actual, tc.output)
+			}
+		})
+	}
+}
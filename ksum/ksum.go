@@ -0,0 +1,197 @@
+// Package ksum finds k numbers that add up to a target
+// like 2020/01's expense report, or 2020/09's XMAS validation
+//
+// each number can only be used as many times as it shows up,
+// and solutions are distinct by their values, not by which positions they came from
+package ksum
+
+import "sort"
+
+// Solve finds every distinct set of k numbers from nums that add up to target
+// each solution is sorted, and the solutions are sorted too
+//
+// the algorithm depends on k: a hash lookup for 2, sorting and closing in from both ends for 3 and 4,
+// and meeting in the middle beyond that
+func Solve(nums []int, k, target int) [][]int {
+	var solutions [][]int
+	switch {
+	case k <= 0 || k > len(nums):
+		return [][]int{}
+	case k == 1:
+		solutions = one(nums, target)
+	case k == 2:
+		solutions = hashed(nums, target)
+	case k <= 4:
+		solutions = twoPointer(nums, k, target)
+	default:
+		solutions = meetInTheMiddle(nums, k, target)
+	}
+
+	sort.Slice(solutions, func(i, j int) bool {
+		return less(solutions[i], solutions[j])
+	})
+	return solutions
+}
+
+// Any is whether any k numbers from nums add up to target
+func Any(nums []int, k, target int) bool {
+	return len(Solve(nums, k, target)) > 0
+}
+
+func one(nums []int, target int) [][]int {
+	for _, n := range nums {
+		if n == target {
+			return [][]int{{n}}
+		}
+	}
+	return [][]int{}
+}
+
+// hashed is 2-sum, looking up what each number needs to reach target
+func hashed(nums []int, target int) [][]int {
+	counts := map[int]int{}
+	for _, n := range nums {
+		counts[n]++
+	}
+
+	solutions := [][]int{}
+	for n := range counts {
+		m := target - n
+		if n < m && counts[m] > 0 {
+			solutions = append(solutions, []int{n, m})
+		} else if n == m && counts[n] > 1 {
+			solutions = append(solutions, []int{n, n})
+		}
+	}
+	return solutions
+}
+
+// twoPointer sorts nums, fixes all but the last two numbers,
+// and then closes in on the last two from both ends
+// skipping over repeated values means every solution is only found once
+func twoPointer(nums []int, k, target int) [][]int {
+	sorted := append([]int{}, nums...)
+	sort.Ints(sorted)
+
+	solutions := [][]int{}
+	var walk func(start, k, target int, prefix []int)
+	walk = func(start, k, target int, prefix []int) {
+		if k == 2 {
+			for lo, hi := start, len(sorted)-1; lo < hi; {
+				switch sum := sorted[lo] + sorted[hi]; {
+				case sum < target:
+					lo++
+				case sum > target:
+					hi--
+				default:
+					solutions = append(solutions, append(append([]int{}, prefix...), sorted[lo], sorted[hi]))
+					for lo++; lo < hi && sorted[lo] == sorted[lo-1]; lo++ {
+					}
+					for hi--; lo < hi && sorted[hi] == sorted[hi+1]; hi-- {
+					}
+				}
+			}
+			return
+		}
+
+		for i := start; i <= len(sorted)-k; i++ {
+			if i > start && sorted[i] == sorted[i-1] {
+				continue
+			}
+			walk(i+1, k-1, target-sorted[i], append(prefix, sorted[i]))
+		}
+	}
+	walk(0, k, target, []int{})
+
+	return solutions
+}
+
+// meetInTheMiddle splits each solution into a front half and a back half
+// every front half is remembered by its sum, and then every back half looks up the front halves it needs
+// to only find each combination of positions once, the front half has to come entirely before the back half
+func meetInTheMiddle(nums []int, k, target int) [][]int {
+	sorted := append([]int{}, nums...)
+	sort.Ints(sorted)
+
+	front, back := k/2, k-k/2
+
+	// every front half, by its sum
+	fronts := map[int][][]int{}
+	combinations(len(sorted), front, func(positions []int) {
+		sum := 0
+		for _, p := range positions {
+			sum += sorted[p]
+		}
+		fronts[sum] = append(fronts[sum], append([]int{}, positions...))
+	})
+
+	seen := map[string]bool{}
+	solutions := [][]int{}
+	combinations(len(sorted), back, func(positions []int) {
+		sum := 0
+		for _, p := range positions {
+			sum += sorted[p]
+		}
+
+		for _, f := range fronts[target-sum] {
+			if f[len(f)-1] >= positions[0] {
+				continue
+			}
+
+			solution := make([]int, 0, k)
+			for _, p := range f {
+				solution = append(solution, sorted[p])
+			}
+			for _, p := range positions {
+				solution = append(solution, sorted[p])
+			}
+
+			// the positions are all increasing, so the solution is already sorted
+			if key := keyOf(solution); !seen[key] {
+				seen[key] = true
+				solutions = append(solutions, solution)
+			}
+		}
+	})
+
+	return solutions
+}
+
+// combinations calls fn with every increasing set of k positions out of n
+// fn must not hold on to positions, it gets reused
+func combinations(n, k int, fn func(positions []int)) {
+	positions := make([]int, k)
+	var walk func(i, start int)
+	walk = func(i, start int) {
+		if i == k {
+			fn(positions)
+			return
+		}
+		for p := start; p <= n-(k-i); p++ {
+			positions[i] = p
+			walk(i+1, p+1)
+		}
+	}
+	walk(0, 0)
+}
+
+func keyOf(solution []int) string {
+	b := make([]byte, 0, 8*len(solution))
+	for _, n := range solution {
+		for shift := 0; shift < 64; shift += 8 {
+			b = append(b, byte(uint64(n)>>shift))
+		}
+	}
+	return string(b)
+}
+
+func less(a, b []int) bool {
+	for i := range a {
+		if i >= len(b) {
+			return false
+		} else if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
@@ -0,0 +1,139 @@
+package ksum
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func solutionsEqual(a, b [][]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if less(a[i], b[i]) || less(b[i], a[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// bruteForce tries every combination of positions, which is as obviously right as it is slow
+func bruteForce(nums []int, k, target int) [][]int {
+	sorted := append([]int{}, nums...)
+	sort.Ints(sorted)
+
+	seen := map[string]bool{}
+	solutions := [][]int{}
+	if k <= 0 || k > len(nums) {
+		return solutions
+	}
+	combinations(len(sorted), k, func(positions []int) {
+		sum := 0
+		solution := make([]int, 0, k)
+		for _, p := range positions {
+			sum += sorted[p]
+			solution = append(solution, sorted[p])
+		}
+		if sum == target && !seen[keyOf(solution)] {
+			seen[keyOf(solution)] = true
+			solutions = append(solutions, solution)
+		}
+	})
+	sort.Slice(solutions, func(i, j int) bool {
+		return less(solutions[i], solutions[j])
+	})
+	return solutions
+}
+
+// the example from 2020/01
+var expenses = []int{1721, 979, 366, 299, 675, 1456}
+
+func TestSolve(t *testing.T) {
+	for _, tc := range []struct {
+		nums     []int
+		k        int
+		target   int
+		expected [][]int
+	}{
+		{expenses, 2, 2020, [][]int{{299, 1721}}},
+		{expenses, 3, 2020, [][]int{{366, 675, 979}}},
+		{expenses, 1, 366, [][]int{{366}}},
+		{expenses, 2, 1, [][]int{}},
+		{expenses, 7, 2020, [][]int{}},
+		{expenses, 0, 0, [][]int{}},
+		// a number can't be paired with itself
+		{[]int{1010, 1}, 2, 2020, [][]int{}},
+		// unless it shows up twice
+		{[]int{1010, 1, 1010}, 2, 2020, [][]int{{1010, 1010}}},
+		// repeats only count as one solution
+		{[]int{1, 2, 1, 2, 3, 0}, 2, 3, [][]int{{0, 3}, {1, 2}}},
+		{[]int{-1, 0, 1, 2, -1, -4}, 3, 0, [][]int{{-1, -1, 2}, {-1, 0, 1}}},
+		{[]int{1, 0, -1, 0, -2, 2}, 4, 0, [][]int{{-2, -1, 1, 2}, {-2, 0, 0, 2}, {-1, 0, 0, 1}}},
+		{[]int{1, 2, 3, 4, 5, 6, 7}, 5, 20, [][]int{{1, 2, 4, 6, 7}, {1, 3, 4, 5, 7}, {2, 3, 4, 5, 6}}},
+	} {
+		if actual := Solve(tc.nums, tc.k, tc.target); !solutionsEqual(actual, tc.expected) {
+			t.Errorf("Expected %v for %d-sum to %d of %v, got %v", tc.expected, tc.k, tc.target, tc.nums, actual)
+		}
+	}
+}
+
+func TestAny(t *testing.T) {
+	if !Any(expenses, 2, 2020) {
+		t.Errorf("Expected a pair summing to 2020 in %v", expenses)
+	}
+	if Any(expenses, 2, 2021) {
+		t.Errorf("Expected no pair summing to 2021 in %v", expenses)
+	}
+}
+
+// every algorithm has to agree with trying everything, whichever k they're picked for
+func TestAgainstBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	algorithms := map[string]func([]int, int, int) [][]int{
+		"twoPointer":      twoPointer,
+		"meetInTheMiddle": meetInTheMiddle,
+	}
+
+	for run := 0; run < 200; run++ {
+		nums := make([]int, 4+r.Intn(10))
+		for i := range nums {
+			nums[i] = r.Intn(21) - 10
+		}
+		target := r.Intn(31) - 15
+
+		for k := 2; k <= 6 && k <= len(nums); k++ {
+			expected := bruteForce(nums, k, target)
+
+			if actual := Solve(nums, k, target); !solutionsEqual(actual, expected) {
+				t.Fatalf("Expected %v for %d-sum to %d of %v, got %v", expected, k, target, nums, actual)
+			}
+			for name, fn := range algorithms {
+				actual := fn(nums, k, target)
+				sort.Slice(actual, func(i, j int) bool {
+					return less(actual[i], actual[j])
+				})
+				if !solutionsEqual(actual, expected) {
+					t.Fatalf("Expected %v from %s for %d-sum to %d of %v, got %v", expected, name, k, target, nums, actual)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkSolve(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	nums := make([]int, 200)
+	for i := range nums {
+		nums[i] = r.Intn(2020)
+	}
+
+	for k := 2; k <= 5; k++ {
+		b.Run(fmt.Sprintf("k=%d", k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Solve(nums, k, 2020)
+			}
+		})
+	}
+}
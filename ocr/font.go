@@ -0,0 +1,343 @@
+package ocr
+
+// a font is every letter we know how to read, drawn the way the puzzles draw them
+// '#' is lit and '.' is dark
+type font struct {
+	height  int
+	letters map[rune][]string
+}
+
+// font4x6 is the font most puzzles use (e.g. 2019/08 and 2021/13)
+// most letters are 4 wide with a 1 pixel gap, but a few (like Y) are wider
+var font4x6 = font{6, map[rune][]string{
+	'A': {
+		".##.",
+		"#..#",
+		"#..#",
+		"####",
+		"#..#",
+		"#..#",
+	},
+	'B': {
+		"###.",
+		"#..#",
+		"###.",
+		"#..#",
+		"#..#",
+		"###.",
+	},
+	'C': {
+		".##.",
+		"#..#",
+		"#...",
+		"#...",
+		"#..#",
+		".##.",
+	},
+	'E': {
+		"####",
+		"#...",
+		"###.",
+		"#...",
+		"#...",
+		"####",
+	},
+	'F': {
+		"####",
+		"#...",
+		"###.",
+		"#...",
+		"#...",
+		"#...",
+	},
+	'G': {
+		".##.",
+		"#..#",
+		"#...",
+		"#.##",
+		"#..#",
+		".###",
+	},
+	'H': {
+		"#..#",
+		"#..#",
+		"####",
+		"#..#",
+		"#..#",
+		"#..#",
+	},
+	'I': {
+		"###",
+		".#.",
+		".#.",
+		".#.",
+		".#.",
+		"###",
+	},
+	'J': {
+		"..##",
+		"...#",
+		"...#",
+		"...#",
+		"#..#",
+		".##.",
+	},
+	'K': {
+		"#..#",
+		"#.#.",
+		"##..",
+		"#.#.",
+		"#.#.",
+		"#..#",
+	},
+	'L': {
+		"#...",
+		"#...",
+		"#...",
+		"#...",
+		"#...",
+		"####",
+	},
+	'O': {
+		".##.",
+		"#..#",
+		"#..#",
+		"#..#",
+		"#..#",
+		".##.",
+	},
+	'P': {
+		"###.",
+		"#..#",
+		"#..#",
+		"###.",
+		"#...",
+		"#...",
+	},
+	'R': {
+		"###.",
+		"#..#",
+		"#..#",
+		"###.",
+		"#.#.",
+		"#..#",
+	},
+	'S': {
+		".###",
+		"#...",
+		"#...",
+		".##.",
+		"...#",
+		"###.",
+	},
+	'U': {
+		"#..#",
+		"#..#",
+		"#..#",
+		"#..#",
+		"#..#",
+		".##.",
+	},
+	'Y': {
+		"#...#",
+		"#...#",
+		".#.#.",
+		"..#..",
+		"..#..",
+		"..#..",
+	},
+	'Z': {
+		"####",
+		"...#",
+		"..#.",
+		".#..",
+		"#...",
+		"####",
+	},
+}}
+
+// font6x10 is the bigger font, from puzzles like 2018/10
+var font6x10 = font{10, map[rune][]string{
+	'A': {
+		"..##..",
+		".#..#.",
+		"#....#",
+		"#....#",
+		"#....#",
+		"######",
+		"#....#",
+		"#....#",
+		"#....#",
+		"#....#",
+	},
+	'B': {
+		"#####.",
+		"#....#",
+		"#....#",
+		"#....#",
+		"#####.",
+		"#....#",
+		"#....#",
+		"#....#",
+		"#....#",
+		"#####.",
+	},
+	'C': {
+		".####.",
+		"#....#",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#....#",
+		".####.",
+	},
+	'E': {
+		"######",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#####.",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#.....",
+		"######",
+	},
+	'F': {
+		"######",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#####.",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#.....",
+	},
+	'G': {
+		".####.",
+		"#....#",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#..###",
+		"#....#",
+		"#....#",
+		"#...##",
+		".###.#",
+	},
+	'H': {
+		"#....#",
+		"#....#",
+		"#....#",
+		"#....#",
+		"######",
+		"#....#",
+		"#....#",
+		"#....#",
+		"#....#",
+		"#....#",
+	},
+	'J': {
+		"...###",
+		"....#.",
+		"....#.",
+		"....#.",
+		"....#.",
+		"....#.",
+		"....#.",
+		"#...#.",
+		"#...#.",
+		".###..",
+	},
+	'K': {
+		"#....#",
+		"#...#.",
+		"#..#..",
+		"#.#...",
+		"##....",
+		"##....",
+		"#.#...",
+		"#..#..",
+		"#...#.",
+		"#....#",
+	},
+	'L': {
+		"#.....",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#.....",
+		"######",
+	},
+	'N': {
+		"#....#",
+		"##...#",
+		"##...#",
+		"#.#..#",
+		"#.#..#",
+		"#..#.#",
+		"#..#.#",
+		"#...##",
+		"#...##",
+		"#....#",
+	},
+	'P': {
+		"#####.",
+		"#....#",
+		"#....#",
+		"#....#",
+		"#####.",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#.....",
+		"#.....",
+	},
+	'R': {
+		"#####.",
+		"#....#",
+		"#....#",
+		"#....#",
+		"#####.",
+		"#..#..",
+		"#...#.",
+		"#...#.",
+		"#....#",
+		"#....#",
+	},
+	'X': {
+		"#....#",
+		"#....#",
+		".#..#.",
+		".#..#.",
+		"..##..",
+		"..##..",
+		".#..#.",
+		".#..#.",
+		"#....#",
+		"#....#",
+	},
+	'Z': {
+		"######",
+		".....#",
+		".....#",
+		"....#.",
+		"...#..",
+		"..#...",
+		".#....",
+		"#.....",
+		"#.....",
+		"######",
+	},
+}}
+
+var fonts = []font{font4x6, font6x10}
@@ -0,0 +1,214 @@
+// Package ocr reads the block letters some puzzles draw their answers in
+//
+// both the common 4x6 font and the bigger 6x10 one are supported,
+// picked by how tall the lit pixels are
+package ocr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gitlab.com/travisby/advent/geom"
+	"gitlab.com/travisby/advent/grid"
+)
+
+var ErrEmpty = errors.New("Nothing is lit")
+var ErrUnknownHeight = errors.New("Text is not the height of any known font")
+var ErrUnknownLetter = errors.New("Unrecognized letter")
+
+// Unknown is what an unrecognized letter reads as
+const Unknown = '?'
+
+// Parse reads text drawn with '#' as lit, and anything else as dark
+// e.g. the String() of a 2019/08 image or a 2021/13 piece of paper
+func Parse(s string) (string, error) {
+	var pixels [][]bool
+	for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+		row := make([]bool, 0, len(line))
+		for _, c := range line {
+			row = append(row, c == '#')
+		}
+		pixels = append(pixels, row)
+	}
+	return read(pixels)
+}
+
+// Points reads text drawn with every point given lit
+// the points can be anywhere, only where they are relative to each other matters
+func Points(ps []geom.Point) (string, error) {
+	if len(ps) == 0 {
+		return "", ErrEmpty
+	}
+
+	b := geom.Bounds(ps[0], ps...)
+	pixels := make([][]bool, b.Height())
+	for y := range pixels {
+		pixels[y] = make([]bool, b.Width())
+	}
+	for _, p := range ps {
+		pixels[p.Y-b.Min.Y][p.X-b.Min.X] = true
+	}
+	return read(pixels)
+}
+
+// Grid reads text drawn in g, with lit deciding which cells are lit
+func Grid[T any](g *grid.Grid[T], lit func(T) bool) (string, error) {
+	pixels := make([][]bool, g.Height())
+	for y := range pixels {
+		pixels[y] = make([]bool, g.Width())
+		for x := range pixels[y] {
+			pixels[y][x] = lit(g.Get(geom.Pt(x, y)))
+		}
+	}
+	return read(pixels)
+}
+
+// read works out the font from the height of the text, and then reads it one letter at a time
+// letters are split wherever there's a completely dark column
+// unrecognized letters read as Unknown, and make for an error describing the first of them
+func read(pixels [][]bool) (string, error) {
+	pixels = trimRows(pixels)
+	if len(pixels) == 0 {
+		return "", ErrEmpty
+	}
+
+	var f *font
+	for i := range fonts {
+		if fonts[i].height == len(pixels) {
+			f = &fonts[i]
+		}
+	}
+	if f == nil {
+		return "", fmt.Errorf("%w: %d pixels tall", ErrUnknownHeight, len(pixels))
+	}
+
+	var sb strings.Builder
+	var err error
+	for i, letter := range splitLetters(pixels) {
+		if r, ok := f.lookup(letter); ok {
+			sb.WriteRune(r)
+			continue
+		}
+
+		sb.WriteRune(Unknown)
+		if err == nil {
+			err = fmt.Errorf("%w (letter %d):\n%s", ErrUnknownLetter, i+1, strings.Join(letter, "\n"))
+		}
+	}
+	return sb.String(), err
+}
+
+func (f *font) lookup(letter []string) (rune, bool) {
+	for r, drawn := range f.letters {
+		if equals(trimColumns(drawn), letter) {
+			return r, true
+		}
+	}
+	return 0, false
+}
+
+// trimRows drops the dark rows above and below the text
+func trimRows(pixels [][]bool) [][]bool {
+	lit := func(row []bool) bool {
+		for _, p := range row {
+			if p {
+				return true
+			}
+		}
+		return false
+	}
+
+	for len(pixels) > 0 && !lit(pixels[0]) {
+		pixels = pixels[1:]
+	}
+	for len(pixels) > 0 && !lit(pixels[len(pixels)-1]) {
+		pixels = pixels[:len(pixels)-1]
+	}
+	return pixels
+}
+
+// splitLetters cuts the text into letters at every dark column
+// each letter is drawn the same way the fonts are
+func splitLetters(pixels [][]bool) [][]string {
+	width := 0
+	for _, row := range pixels {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	at := func(x, y int) bool {
+		return x < len(pixels[y]) && pixels[y][x]
+	}
+	litColumn := func(x int) bool {
+		for y := range pixels {
+			if at(x, y) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var letters [][]string
+	for x := 0; x < width; {
+		if !litColumn(x) {
+			x++
+			continue
+		}
+
+		start := x
+		for x < width && litColumn(x) {
+			x++
+		}
+
+		letter := make([]string, len(pixels))
+		for y := range pixels {
+			row := make([]byte, 0, x-start)
+			for i := start; i < x; i++ {
+				if at(i, y) {
+					row = append(row, '#')
+				} else {
+					row = append(row, '.')
+				}
+			}
+			letter[y] = string(row)
+		}
+		letters = append(letters, letter)
+	}
+	return letters
+}
+
+// trimColumns drops the dark columns either side of a letter
+// (e.g. the 4x6 'I' is only 3 pixels wide)
+func trimColumns(letter []string) []string {
+	left, right := len(letter[0]), 0
+	for _, row := range letter {
+		if i := strings.IndexByte(row, '#'); i != -1 && i < left {
+			left = i
+		}
+		if i := strings.LastIndexByte(row, '#'); i+1 > right {
+			right = i + 1
+		}
+	}
+	if left >= right {
+		return letter
+	}
+
+	trimmed := make([]string, len(letter))
+	for i, row := range letter {
+		trimmed[i] = row[left:right]
+	}
+	return trimmed
+}
+
+func equals(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+package ocr
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"gitlab.com/travisby/advent/geom"
+	"gitlab.com/travisby/advent/grid"
+)
+
+// 2019/08's answer
+const lbrce = `#....###..###...##..####.
+#....#..#.#..#.#..#.#....
+#....###..#..#.#....###..
+#....#..#.###..#....#....
+#....#..#.#.#..#..#.#....
+####.###..#..#..##..####.
+`
+
+// 2021/13's answer
+const fpekbejl = `####.###..####.#..#.###..####...##.#...
+#....#..#.#....#.#..#..#.#.......#.#...
+###..#..#.###..##...###..###.....#.#...
+#....###..#....#.#..#..#.#.......#.#...
+#....#....#....#.#..#..#.#....#..#.#...
+#....#....####.#..#.###..####..##..####
+`
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		output string
+	}{
+		{"2019/08", lbrce, "LBRCE"},
+		{"2021/13", fpekbejl, "FPEKBEJL"},
+		{"Spaces", strings.ReplaceAll(lbrce, ".", " "), "LBRCE"},
+		{"Padded", "\n......\n" + strings.ReplaceAll(lbrce, "\n", "..\n") + "......\n", "LBRCE"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual, err := Parse(tc.input); err != nil {
+				t.Fatal(err)
+			} else if actual != tc.output {
+				t.Fatalf("Expected %q, got %q", tc.output, actual)
+			}
+		})
+	}
+}
+
+// every letter of every font should be read back as itself
+func TestFonts(t *testing.T) {
+	for _, f := range fonts {
+		letters := []rune{}
+		for r := range f.letters {
+			letters = append(letters, r)
+		}
+		sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+
+		rows := make([]string, f.height)
+		for _, r := range letters {
+			for y := range rows {
+				rows[y] += f.letters[r][y] + ".."
+			}
+		}
+
+		if actual, err := Parse(strings.Join(rows, "\n")); err != nil {
+			t.Fatal(err)
+		} else if actual != string(letters) {
+			t.Errorf("Expected %q, got %q", string(letters), actual)
+		}
+	}
+}
+
+func TestPoints(t *testing.T) {
+	var ps []geom.Point
+	for y, row := range strings.Split(lbrce, "\n") {
+		for x, c := range row {
+			if c == '#' {
+				// anywhere on the plane is fine
+				ps = append(ps, geom.Pt(x-100, y+42))
+			}
+		}
+	}
+
+	if actual, err := Points(ps); err != nil {
+		t.Fatal(err)
+	} else if actual != "LBRCE" {
+		t.Fatalf("Expected \"LBRCE\", got %q", actual)
+	}
+
+	if _, err := Points(nil); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	g, err := grid.Parse(strings.NewReader(fpekbejl), func(r rune) (bool, error) {
+		return r == '#', nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual, err := Grid(g, func(b bool) bool { return b }); err != nil {
+		t.Fatal(err)
+	} else if actual != "FPEKBEJL" {
+		t.Fatalf("Expected \"FPEKBEJL\", got %q", actual)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	unknown := `####.#..#
+#....#..#
+###..#..#
+#.....##.
+#....#..#
+####.#..#
+`
+	actual, err := Parse(unknown)
+	if !errors.Is(err, ErrUnknownLetter) {
+		t.Errorf("Expected ErrUnknownLetter, got %v", err)
+	} else if actual != "E?" {
+		t.Errorf("Expected the letters we can read to still be read, got %q", actual)
+	}
+
+	if _, err := Parse("#\n#\n#\n"); !errors.Is(err, ErrUnknownHeight) {
+		t.Errorf("Expected ErrUnknownHeight, got %v", err)
+	}
+	if _, err := Parse("....\n....\n"); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+// Package pqueue is a generic min-priority queue
+//
+// This started life as 2021/15's modified copy of https://pkg.go.dev/container/heap#example-package-PriorityQueue
+// the differences from that example are:
+//   - it's a min-heap, because that's what Dijkstra wants
+//   - values are looked up by an index map, so Contains is O(1) and Update is O(log n)
+//     instead of scanning for the *Item
+//   - Push/Pop are typed, container/heap's interface{} stays hidden in here
+package pqueue
+
+import (
+	"container/heap"
+	"errors"
+)
+
+var ErrNotFound = errors.New("Value is not in the queue")
+
+// An item is something we manage in a priority queue.
+type item[T comparable] struct {
+	value    T   // The value of the item; must be unique within the queue.
+	priority int // The priority of the item in the queue.
+}
+
+// items implements heap.Interface
+// keeping index up to date with where every value lives in the heap
+type items[T comparable] struct {
+	heap  []item[T]
+	index map[T]int
+}
+
+func (is items[T]) Len() int { return len(is.heap) }
+
+func (is items[T]) Less(i, j int) bool {
+	return is.heap[i].priority < is.heap[j].priority
+}
+
+func (is items[T]) Swap(i, j int) {
+	is.heap[i], is.heap[j] = is.heap[j], is.heap[i]
+	is.index[is.heap[i].value] = i
+	is.index[is.heap[j].value] = j
+}
+
+func (is *items[T]) Push(x interface{}) {
+	// only ever called by us, with an item[T]
+	it := x.(item[T])
+	is.index[it.value] = len(is.heap)
+	is.heap = append(is.heap, it)
+}
+
+func (is *items[T]) Pop() interface{} {
+	n := len(is.heap)
+	it := is.heap[n-1]
+	is.heap = is.heap[:n-1]
+	delete(is.index, it.value)
+	return it
+}
+
+// PriorityQueue pops its lowest priority value first
+// each value can only be in the queue once
+type PriorityQueue[T comparable] struct {
+	items items[T]
+}
+
+// New creates an empty PriorityQueue
+func New[T comparable]() *PriorityQueue[T] {
+	return &PriorityQueue[T]{items[T]{index: make(map[T]int)}}
+}
+
+// Len is the number of values in the queue
+func (pq *PriorityQueue[T]) Len() int {
+	return pq.items.Len()
+}
+
+// Push adds value to the queue
+// if it's already queued, its priority is changed instead
+func (pq *PriorityQueue[T]) Push(value T, priority int) {
+	if i, ok := pq.items.index[value]; ok {
+		pq.items.heap[i].priority = priority
+		heap.Fix(&pq.items, i)
+		return
+	}
+	heap.Push(&pq.items, item[T]{value, priority})
+}
+
+// Pop removes and returns the value with the lowest priority
+// returning false if the queue is empty
+func (pq *PriorityQueue[T]) Pop() (value T, priority int, ok bool) {
+	if pq.Len() == 0 {
+		return value, 0, false
+	}
+	it := heap.Pop(&pq.items).(item[T])
+	return it.value, it.priority, true
+}
+
+// Peek returns the value with the lowest priority without removing it
+// returning false if the queue is empty
+func (pq *PriorityQueue[T]) Peek() (value T, priority int, ok bool) {
+	if pq.Len() == 0 {
+		return value, 0, false
+	}
+	return pq.items.heap[0].value, pq.items.heap[0].priority, true
+}
+
+// Contains reports whether value is queued
+func (pq *PriorityQueue[T]) Contains(value T) bool {
+	_, ok := pq.items.index[value]
+	return ok
+}
+
+// Priority returns value's current priority
+// returning false if it isn't queued
+func (pq *PriorityQueue[T]) Priority(value T) (int, bool) {
+	i, ok := pq.items.index[value]
+	if !ok {
+		return 0, false
+	}
+	return pq.items.heap[i].priority, true
+}
+
+// Update changes the priority of an already queued value
+// e.g. the decrease-key step of Dijkstra's algorithm
+func (pq *PriorityQueue[T]) Update(value T, priority int) error {
+	i, ok := pq.items.index[value]
+	if !ok {
+		return ErrNotFound
+	}
+	pq.items.heap[i].priority = priority
+	heap.Fix(&pq.items, i)
+	return nil
+}
+
+// Remove takes value out of the queue
+func (pq *PriorityQueue[T]) Remove(value T) error {
+	i, ok := pq.items.index[value]
+	if !ok {
+		return ErrNotFound
+	}
+	heap.Remove(&pq.items, i)
+	return nil
+}
@@ -0,0 +1,198 @@
+package pqueue
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestPopOrder(t *testing.T) {
+	pq := New[string]()
+	pq.Push("c", 3)
+	pq.Push("a", 1)
+	pq.Push("d", 4)
+	pq.Push("b", 2)
+
+	if pq.Len() != 4 {
+		t.Fatalf("Expected 4 values, got %d", pq.Len())
+	}
+
+	for _, expected := range []string{"a", "b", "c", "d"} {
+		v, _, ok := pq.Pop()
+		if !ok {
+			t.Fatalf("Expected %q, but the queue was empty", expected)
+		} else if v != expected {
+			t.Errorf("Expected %q, got %q", expected, v)
+		}
+	}
+
+	if _, _, ok := pq.Pop(); ok {
+		t.Errorf("Expected the queue to be empty")
+	}
+}
+
+func TestPeek(t *testing.T) {
+	pq := New[string]()
+	if _, _, ok := pq.Peek(); ok {
+		t.Fatalf("Did not expect to peek into an empty queue")
+	}
+
+	pq.Push("b", 2)
+	pq.Push("a", 1)
+	if v, p, ok := pq.Peek(); !ok || v != "a" || p != 1 {
+		t.Errorf("Expected (a, 1, true), got (%q, %d, %t)", v, p, ok)
+	}
+	if pq.Len() != 2 {
+		t.Errorf("Expected Peek to leave the queue alone, got len %d", pq.Len())
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		priority int
+		first    string
+	}{
+		{"Decrease to the front", "d", 0, "d"},
+		{"Increase to the back", "a", 10, "b"},
+		{"No change", "c", 3, "a"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pq := New[string]()
+			for i, v := range []string{"a", "b", "c", "d"} {
+				pq.Push(v, i+1)
+			}
+
+			if err := pq.Update(tc.value, tc.priority); err != nil {
+				t.Fatal(err)
+			}
+			if p, ok := pq.Priority(tc.value); !ok || p != tc.priority {
+				t.Errorf("Expected priority %d, got (%d, %t)", tc.priority, p, ok)
+			}
+			if v, _, _ := pq.Pop(); v != tc.first {
+				t.Errorf("Expected %q first, got %q", tc.first, v)
+			}
+		})
+	}
+
+	if err := New[string]().Update("z", 1); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPushExistingUpdates(t *testing.T) {
+	pq := New[string]()
+	pq.Push("a", 5)
+	pq.Push("b", 3)
+	pq.Push("a", 1)
+
+	if pq.Len() != 2 {
+		t.Fatalf("Expected pushing a duplicate to not add it twice, got len %d", pq.Len())
+	}
+	if v, p, _ := pq.Pop(); v != "a" || p != 1 {
+		t.Errorf("Expected (a, 1), got (%q, %d)", v, p)
+	}
+}
+
+func TestContainsAndRemove(t *testing.T) {
+	pq := New[int]()
+	pq.Push(1, 1)
+	pq.Push(2, 2)
+	pq.Push(3, 3)
+
+	if !pq.Contains(2) {
+		t.Fatalf("Expected 2 to be queued")
+	}
+	if err := pq.Remove(2); err != nil {
+		t.Fatal(err)
+	}
+	if pq.Contains(2) {
+		t.Errorf("Did not expect 2 to be queued after removing it")
+	}
+	if err := pq.Remove(2); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	pq.Pop()
+	if pq.Contains(1) {
+		t.Errorf("Did not expect 1 to be queued after popping it")
+	}
+	if v, _, _ := pq.Pop(); v != 3 {
+		t.Errorf("Expected 3, got %d", v)
+	}
+}
+
+func TestRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(2021))
+	pq := New[int]()
+	priorities := map[int]int{}
+
+	for i := 0; i < 1000; i++ {
+		priorities[i] = r.Intn(500)
+		pq.Push(i, priorities[i])
+	}
+	// shuffle a bunch of them around
+	for i := 0; i < 1000; i += 3 {
+		priorities[i] = r.Intn(500)
+		if err := pq.Update(i, priorities[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := make([]int, 0, len(priorities))
+	for _, p := range priorities {
+		expected = append(expected, p)
+	}
+	sort.Ints(expected)
+
+	for i, e := range expected {
+		v, p, ok := pq.Pop()
+		if !ok || p != e || priorities[v] != p {
+			t.Fatalf("Pop %d: expected priority %d, got (%d, %d, %t)", i, e, v, p, ok)
+		}
+	}
+}
+
+func BenchmarkPushPop(b *testing.B) {
+	for _, size := range []int{100, 10000, 100000} {
+		b.Run(fmt.Sprintf("%d", size), func(b *testing.B) {
+			r := rand.New(rand.NewSource(2021))
+			for i := 0; i < b.N; i++ {
+				pq := New[int]()
+				for j := 0; j < size; j++ {
+					pq.Push(j, r.Intn(size))
+				}
+				for pq.Len() > 0 {
+					pq.Pop()
+				}
+			}
+		})
+	}
+}
+
+// this is the access pattern that made 2021/15 part 2 O(n²)
+// every value queued up front, then decrease-key'd over and over
+func BenchmarkDecreaseKey(b *testing.B) {
+	for _, size := range []int{100, 10000, 100000} {
+		b.Run(fmt.Sprintf("%d", size), func(b *testing.B) {
+			pq := New[int]()
+			for j := 0; j < size; j++ {
+				pq.Push(j, 1<<32-1)
+			}
+			r := rand.New(rand.NewSource(2021))
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				v := r.Intn(size)
+				p, _ := pq.Priority(v)
+				if err := pq.Update(v, p-1); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}